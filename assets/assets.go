@@ -0,0 +1,134 @@
+/* Package assets finds the content of space-glide in the data directories and falls back to the content built into the binary */
+package assets
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+/* AppName is the name of the directories of the game inside the XDG directories */
+const AppName = "space-glide"
+
+/* A struct for the directories content is searched in and the directories the game writes to */
+type Library struct {
+	Dirs      []string // The directories searched for content in order
+	UserDir   string   // Where the game saves the campaign and the high scores
+	ConfigDir string   // Where the game saves the settings
+	fallback  fs.FS
+}
+
+/* A function that makes a library, content is searched in dataDir if it is set, then in the XDG data directories, then in the current directory and last in fallback */
+func New(dataDir string, fallback fs.FS) *Library {
+	l := &Library{fallback: fallback}
+	if dataDir != "" {
+		l.Dirs = append(l.Dirs, dataDir)
+	}
+	dataHome := xdgDir("XDG_DATA_HOME", ".local/share")
+	if dataHome != "" {
+		l.Dirs = append(l.Dirs, filepath.Join(dataHome, AppName))
+	}
+	dataDirs := os.Getenv("XDG_DATA_DIRS")
+	if dataDirs == "" {
+		dataDirs = "/usr/local/share:/usr/share"
+	}
+	for _, dir := range filepath.SplitList(dataDirs) {
+		if dir != "" {
+			l.Dirs = append(l.Dirs, filepath.Join(dir, AppName))
+		}
+	}
+	l.Dirs = append(l.Dirs, ".")
+
+	// Everything the game writes goes to the data directory if one was given
+	l.UserDir, l.ConfigDir = dataDir, dataDir
+	if dataDir == "" {
+		l.UserDir = filepath.Join(dataHome, AppName)
+		l.ConfigDir = filepath.Join(xdgDir("XDG_CONFIG_HOME", ".config"), AppName)
+	}
+	return l
+}
+
+/* A function that returns an XDG directory from its environment variable or its default inside the home directory */
+func xdgDir(env, home string) string {
+	if dir := os.Getenv(env); dir != "" {
+		return dir
+	}
+	dir, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, home)
+}
+
+/* A method that opens a file of the content from the first directory that has it, or from the fallback, names use forward slashes like "json/levels.json" */
+func (l *Library) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+	for _, dir := range l.Dirs {
+		file, err := os.DirFS(dir).Open(name)
+		if err == nil {
+			return file, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+	}
+	if l.fallback != nil {
+		return l.fallback.Open(name)
+	}
+	return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+}
+
+/* A method that reads a file of the content as a string */
+func (l *Library) ReadString(name string) (string, error) {
+	data, err := fs.ReadFile(l, name)
+	return string(data), err
+}
+
+/* A method that returns where a file the game writes is saved and makes its directory */
+func (l *Library) UserFile(name string) (string, error) {
+	return makeParent(filepath.Join(l.UserDir, filepath.FromSlash(name)))
+}
+
+/* A method that returns where a settings file is saved and makes its directory */
+func (l *Library) ConfigFile(name string) (string, error) {
+	return makeParent(filepath.Join(l.ConfigDir, filepath.FromSlash(name)))
+}
+
+/* A function that makes the directory of a file and returns the file */
+func makeParent(filename string) (string, error) {
+	if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+		return "", err
+	}
+	return filename, nil
+}
+
+/* A method that returns where a file of the content comes from, "built in" if it is from the fallback */
+func (l *Library) Where(name string) string {
+	for _, dir := range l.Dirs {
+		if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(name))); err == nil {
+			return filepath.Join(dir, filepath.FromSlash(name))
+		}
+	}
+	return "built in"
+}
+
+/* A method that returns a directory with a name inside the user directory and every content directory, like the "mods" directories */
+func (l *Library) Subdirs(name string) []string {
+	dirs := []string{filepath.Join(l.UserDir, name)}
+	for _, dir := range l.Dirs {
+		sub := filepath.Join(dir, name)
+		if sub != dirs[0] {
+			dirs = append(dirs, sub)
+		}
+	}
+	return dirs
+}
+
+/* A method that returns the directories searched for content as one line for the log */
+func (l *Library) String() string {
+	return strings.Join(append(append([]string(nil), l.Dirs...), "built in"), ", ")
+}
@@ -1,193 +1,206 @@
 package main
 
 import (
-	"encoding/json"
-	"io"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
 	"math/rand"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
+	spaceglide "github.com/esa1234567/GoSpaceshipGame"
+	"github.com/esa1234567/GoSpaceshipGame/assets"
+	"github.com/esa1234567/GoSpaceshipGame/game"
+	"github.com/esa1234567/GoSpaceshipGame/mods"
+	"github.com/esa1234567/GoSpaceshipGame/render"
+	"github.com/esa1234567/GoSpaceshipGame/replay"
+	"github.com/esa1234567/GoSpaceshipGame/save"
+	"github.com/esa1234567/GoSpaceshipGame/settings"
+	"github.com/esa1234567/GoSpaceshipGame/sound"
+	"github.com/esa1234567/GoSpaceshipGame/validate"
 	gc "github.com/rthornton128/goncurses"
 	log "github.com/sirupsen/logrus"
 )
 
-/* star_density is how dense the stars are the higher the density the lower the density */
-const star_density = 0.005
+/* skipMainMenu is if you want to skip the main menu if it is true you skip the main menu otherwise you don't  */
+var skipMainMenu bool
 
-/* planet_density is how dense the planets are the higher the density the lower the density */
-const planet_density = 0.0005
+/* numberOfLevel is the level that you chose used for skipMainMenu */
+var numberOfLevel int
 
-/* ship_ascii is the ascii art for the spaceship */
-var ship_ascii = []string{
-	` ,     `,
-	` |\-   `,
-	`>|^===0`,
-	` |/-    `,
-	` '      `,
-}
+/* mixer plays the sound effects and the music, it is heard only if the audio device could be opened */
+var mixer = sound.NewMixer(settings.DefaultAudio)
 
-/* enemy_ascii is the ascii art for the enemies */
-var enemy_ascii = []string{
-	`  ^^^  `,
-	`{(-+-)}`,
-	`{(-+-)}`,
-	`{(-+-)}`,
-	`  ***  `,
-}
+/* campaignFile is where the campaign progress is saved, it is in the user directory of the library */
+var campaignFile string
 
-/* explosion_ascii is the ascii art for an explosion */
-var explosion_ascii = []string{
-	`.** *. *`,
-	`*. *.*. `,
-	`.** **.*`,
-	`*.*.***.`,
-}
+/* highScoresFile is where the high score tables are saved, it is in the user directory of the library */
+var highScoresFile string
 
-/* skipMainMenu is if you want to skip the main menu if it is true you skip the main menu otherwise you don't  */
-var skipMainMenu bool
+/* library finds the content of the game in the data directories or in the content built into the binary */
+var library *assets.Library
 
-/* numberOfLevel is the level that you chose used for skipMainMenu */
-var numberOfLevel int
+/* content is what the game is loaded from, the library with the chosen content pack laid over it */
+var content fs.FS
 
-/* A json structure for a Character */
-type Character struct {
-	Name       string   `json:"name"`      /* This is the name of the character */
-	AsciiArt   []string `json:"ascii_art"` /* This is the ascii art of the character */
-	Attributes struct { /* These is the attributes of the character */
-		Speed  int    `json:"speed"`  /* The speed of the character */
-		Damage int    `json:"damage"` /* The amount of damage the character can take (health) */
-		Color  string `json:"color"`  /* The colour of the character */
-	} `json:"attributes"`
-}
+/* dataDir is searched for content before everything else and the game saves everything there if it is set */
+var dataDir = flag.String("data-dir", "", "directory searched first for json/, design/ and audio/, and where saves and settings are written")
 
-/* A json structure for the controls */
-type Controls struct {
-	Up    string `json:"up"`    /* This is the control for moving up */
-	Down  string `json:"down"`  /* This is the control for moving down */
-	Left  string `json:"left"`  /* This is the control for moving left */
-	Right string `json:"right"` /* This is the control for moving right */
-	Shoot string `json:"shoot"` /* This is the control for shooting */
-}
+/* seed is the seed of all of the randomness, runs with the same seed have the same starfield and enemies */
+var seed = flag.Int64("seed", 0, "seed for the starfield and the enemies (0 picks a random seed)")
 
-/* A json structure for a level */
-type Level struct {
-	Number  int `json:"number"`
-	Enemies int `json:"enemies"`
-	Time    int `json:"time"`
-	Score   int `json:"score"`
-}
+/* tickRate is how many times a second the game is stepped */
+var tickRate = flag.Int("tick-rate", game.DefaultTickRate, "simulation steps per second")
 
-/* A json structure for all of the levels */
-type Levels struct {
-	Levels []Level `json:"levels"`
-}
+/* audioBackend is where the sound is played */
+var audioBackend = flag.String("audio", sound.BackendAuto, "audio backend: auto, device, null or wav")
 
-/* A json structure for the all of the settings */
-type Settings struct {
-	Controls Controls `json:"controls"`
-}
+/* audioFile is the file the wav audio backend writes to */
+var audioFile = flag.String("audio-file", "space-glide.wav", "file written by the wav audio backend")
 
-/* A json structure for the all of the characters */
-type Characters struct {
-	Characters []Character `json:"characters"`
-}
+/* noAudio turns the sound off, it is the same as the null audio backend */
+var noAudio = flag.Bool("no-audio", false, "play without sound")
 
-/* A function that allows you to change or select a level */
-func SelectLevel(stdscr *gc.Window) Level {
-	file, err := os.Open("json/levels.json")
-	if err != nil {
-		log.Fatal(err)
-	}
-	defer file.Close()
+/* startLevel is the number of the level the game starts in, skipping the menus */
+var startLevel = flag.Int("level", 0, "start straight in the level with this number")
 
-	// Read the JSON data from the file
-	data, err := io.ReadAll(file)
-	if err != nil {
-		log.Fatal(err)
-	}
+/* shipName is the name of the character the game starts with */
+var shipName = flag.String("ship", "", "name of the spaceship to play with")
 
-	// Create a variable to hold the control settings
-	var levels Levels
+/* fps is how many frames a second are drawn at most, 0 draws one after every step */
+var fps = flag.Int("fps", 0, "frames drawn per second at most (0 draws after every simulation step)")
 
-	// Unmarshal the JSON data into the settings variable
-	if err := json.Unmarshal(data, &levels); err != nil {
-		log.Fatal(err)
+/* recordFile is the file the replay of the last run is saved to */
+var recordFile = flag.String("record", "", "file the replay of the last run is saved to (default last.replay in the user data directory)")
+
+/* logFileName is the file the log is written to */
+var logFileName = flag.String("log-file", filepath.Join(os.TempDir(), "space-glide.log"), "file the log is written to")
+
+/* settingsFile is where the settings are saved, it is in the config directory of the library */
+var settingsFile string
+
+/* config is the settings of the game, they are loaded when the game starts */
+var config = settings.Defaults()
+
+/* levelsPerRow is how many levels there are in one row of the levels menu */
+const levelsPerRow = 8
+
+/* A function that allows you to change or select a level, only the levels unlocked in the campaign can be selected */
+func SelectLevel(stdscr *gc.Window, r render.Renderer, levels game.Levels, campaign *save.Campaign) game.Level {
+	if skipMainMenu {
+		skipMainMenu = !skipMainMenu
+		return levels.Levels[numberOfLevel-1]
 	}
-	stdscr.Clear()
-	contents, err := readFile("design/levels_menu.txt")
+	banner, err := readFile("design/levels_menu.txt")
 	if err != nil {
 		log.Fatal(err)
 	}
-	stdscr.MovePrint(0, 0, contents)
 	stdscr.Timeout(-1)
-	if skipMainMenu {
-		skipMainMenu = !skipMainMenu
-		stdscr.Timeout(0)
-		return levels.Levels[numberOfLevel-1]
+	defer stdscr.Timeout(0)
+
+	// Start on the highest unlocked level
+	current := 0
+	for i := range levels.Levels {
+		if campaign.IsUnlocked(levels, i) {
+			current = i
+		}
 	}
 	for {
-		input := stdscr.GetChar()
-		input2 := stdscr.GetChar()
-		if err != nil {
-			log.Fatal(err)
-		}
-		var inputAsNumber int
-		var err error
-		log.Infof("Number selected as string: %s%s", string(input), string(input2))
-		if string(input) == "" {
-			inputAsNumber, err = strconv.Atoi(string(input2))
-		} else {
-			inputAsNumber, err = strconv.Atoi(string(input) + string(input2))
-		}
-		log.Infof("Number selected as integer: %d", inputAsNumber)
-		if err != nil {
-			log.Fatal(err)
+		r.Clear()
+		r.DrawText(0, 0, banner, render.Style{})
+		drawLevelGrid(r, levels, campaign, current)
+		r.Present()
+
+		key := stdscr.GetChar()
+		if key == gc.KEY_RIGHT || key == gc.KEY_LEFT || key == gc.KEY_UP || key == gc.KEY_DOWN {
+			mixer.Play(sound.MenuMove)
 		}
-		if inputAsNumber >= 1 && inputAsNumber <= 40 {
-			log.Infof("Passed Check with number: %d", inputAsNumber)
-			numberOfLevel = inputAsNumber
-			stdscr.Timeout(0)
-			return levels.Levels[inputAsNumber-1]
+		switch key {
+		case gc.KEY_RIGHT:
+			if current < len(levels.Levels)-1 {
+				current++
+			}
+		case gc.KEY_LEFT:
+			if current > 0 {
+				current--
+			}
+		case gc.KEY_DOWN:
+			if current+levelsPerRow < len(levels.Levels) {
+				current += levelsPerRow
+			}
+		case gc.KEY_UP:
+			if current-levelsPerRow >= 0 {
+				current -= levelsPerRow
+			}
+		case gc.KEY_RETURN, gc.KEY_ENTER:
+			if campaign.IsUnlocked(levels, current) {
+				mixer.Play(sound.MenuEnter)
+				log.Infof("Selected level: %d", levels.Levels[current].Number)
+				numberOfLevel = current + 1
+				return levels.Levels[current]
+			}
 		}
 	}
 }
 
-/* A function that allows you to change between spaceships */
-func changeShip(stdscr *gc.Window) Character {
-	file, err := os.Open("json/characters.json")
-	if err != nil {
-		log.Fatal(err)
+/* A function that draws a box for every level with the best score or if the level is locked */
+func drawLevelGrid(r render.Renderer, levels game.Levels, campaign *save.Campaign, current int) {
+	_, cols := r.Size()
+	left := (cols - levelsPerRow*12) / 2
+	for i, level := range levels.Levels {
+		y := 11 + (i/levelsPerRow)*4
+		x := left + (i%levelsPerRow)*12
+		label, info := fmt.Sprintf("(%02d)", level.Number), "  locked  "
+		if campaign.IsUnlocked(levels, i) {
+			info = "          "
+			if best, ok := campaign.Best[level.Number]; ok {
+				info = fmt.Sprintf("best %5d", best)
+			}
+		}
+		style := render.Style{}
+		if i == current {
+			style = render.Style{Color: render.Yellow, Bold: true}
+		}
+		r.DrawText(y, x, " __________ ", style)
+		r.DrawText(y+1, x, fmt.Sprintf("|   %s   |", label), style)
+		r.DrawText(y+2, x, "|"+info+"|", style)
+		r.DrawText(y+3, x, "|__________|", style)
 	}
-	defer file.Close()
+}
 
-	// Read the JSON data from the file
-	data, err := io.ReadAll(file)
+/* A function that allows you to change between spaceships, it starts on the current one */
+func changeShip(stdscr *gc.Window, current game.Character) game.Character {
+	characters, err := game.LoadCharacters(content, "json/characters.json")
 	if err != nil {
 		log.Fatal(err)
 	}
-
-	// Create a variable to hold the character data
-	var characters Characters
-
-	// Unmarshal the JSON data into the characters variable
-	if err := json.Unmarshal(data, &characters); err != nil {
-		log.Fatal(err)
+	if len(characters.Characters) == 0 {
+		log.Fatal("there are no spaceships to choose from")
 	}
+	// Every spaceship gets a column of the same width
 	_, maxX := stdscr.MaxYX()
-	displayWidth := maxX / 3
+	displayWidth := maxX / len(characters.Characters)
 
-	// Initialize the character index to display in the middle
-	currentCharacterIndex := 1
+	// Start on the spaceship that is being used, the first one if it isn't in the list
+	currentCharacterIndex := 0
+	for i, character := range characters.Characters {
+		if character.Name == current.Name {
+			currentCharacterIndex = i
+		}
+	}
 	for {
 		// Clear the screen
 		stdscr.Clear()
 
-		// Display characters in the left, middle, and right
+		// Display the characters side by side
 		for i, character := range characters.Characters {
-			x := (i%3)*displayWidth + 12
+			x := i*displayWidth + 2
 			if i == currentCharacterIndex {
 				stdscr.AttrOn(gc.A_BOLD)
 			}
@@ -201,9 +214,13 @@ func changeShip(stdscr *gc.Window) Character {
 			}
 
 			// Display character attributes
-			stdscr.MovePrintf(len(character.AsciiArt)+2, x, "Speed: %d", character.Attributes.Speed)
-			stdscr.MovePrintf(len(character.AsciiArt)+3, x, "Damage: %d", character.Attributes.Damage)
-			stdscr.MovePrintf(len(character.AsciiArt)+4, x, "Color: %s", character.Attributes.Color)
+			attributes := character.Attributes
+			stdscr.MovePrintf(len(character.AsciiArt)+2, x, "Health: %d", attributes.MaxHealth)
+			stdscr.MovePrintf(len(character.AsciiArt)+3, x, "Shield: %d", attributes.Shield)
+			stdscr.MovePrintf(len(character.AsciiArt)+4, x, "Firepower: %d", attributes.Firepower)
+			stdscr.MovePrintf(len(character.AsciiArt)+5, x, "Fire rate: %.1fx", attributes.FireRate)
+			stdscr.MovePrintf(len(character.AsciiArt)+6, x, "Speed: %d", attributes.Speed)
+			stdscr.MovePrintf(len(character.AsciiArt)+7, x, "Color: %s", attributes.Color)
 
 			if i == currentCharacterIndex {
 				stdscr.AttrOff(gc.A_BOLD)
@@ -218,6 +235,12 @@ func changeShip(stdscr *gc.Window) Character {
 
 		// Handle navigation
 		switch key {
+		case gc.KEY_RIGHT, gc.KEY_LEFT:
+			mixer.Play(sound.MenuMove)
+		case gc.KEY_RETURN:
+			mixer.Play(sound.MenuEnter)
+		}
+		switch key {
 		case gc.KEY_RIGHT:
 			// Move to the next character on the right
 			if currentCharacterIndex < len(characters.Characters)-1 {
@@ -229,92 +252,18 @@ func changeShip(stdscr *gc.Window) Character {
 				currentCharacterIndex--
 			}
 		case gc.KEY_RETURN:
-			ship_ascii = characters.Characters[currentCharacterIndex].AsciiArt
 			return characters.Characters[currentCharacterIndex]
 		}
 	}
 }
 
-/* A function that generates a field (*gc.Pad) and returns it */
-func genStarfield(pl, pc int) *gc.Pad {
-	pad, err := gc.NewPad(pl, pc)
-	if err != nil {
-		log.Fatal(err)
-	}
-	stars := int(float64(pc*pl) * star_density)
-	planets := int(float64(pc*pl) * planet_density)
-	for i := 0; i < stars; i++ {
-		y, x := rand.Intn(pl), rand.Intn(pc)
-		c := int16(rand.Intn(4) + 1)
-		pad.AttrOn(gc.A_BOLD | gc.ColorPair(c))
-		pad.MovePrint(y, x, ".")
-		pad.AttrOff(gc.A_BOLD | gc.ColorPair(c))
-	}
-	for i := 0; i < planets; i++ {
-		y, x := rand.Intn(pl), rand.Intn(pc)
-		c := int16(rand.Intn(2) + 5)
-		pad.ColorOn(c)
-		if i%2 == 0 {
-			pad.MoveAddChar(y, x, 'O')
-		}
-		pad.MoveAddChar(y, x, 'o')
-		pad.ColorOff(c)
-	}
-	return pad
-}
-
-/* A function that populates the settings doesn't show the controls menu */
-func NewControls() Controls {
-	// Open the JSON file for reading
-	file, err := os.Open("json/settings.json")
-	if err != nil {
-		log.Fatal(err)
-	}
-	defer file.Close()
-
-	// Read the JSON data from the file
-	data, err := io.ReadAll(file)
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	// Create a variable to hold the control settings
-	var settings Settings
-
-	// Unmarshal the JSON data into the settings variable
-	if err := json.Unmarshal(data, &settings); err != nil {
-		log.Fatal(err)
-	}
-	return settings.Controls
-}
-
-/* A function that allows you to see and change the users controls and populates the controls */
-func controls(stdscr *gc.Window) Controls {
+/* A function that allows you to see and change the users controls, the settings are saved when you leave the menu */
+func controls(stdscr *gc.Window) settings.Controls {
 	stdscr.Clear()
 	lines, cols := stdscr.MaxYX()
 	centerX := (cols - 120) / 2
 	centerY := (lines - 40) / 2
 
-	// Open the JSON file for reading
-	file, err := os.Open("json/settings.json")
-	if err != nil {
-		log.Fatal(err)
-	}
-	defer file.Close()
-
-	// Read the JSON data from the file
-	data, err := io.ReadAll(file)
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	// Create a variable to hold the control settings
-	var settings Settings
-
-	// Unmarshal the JSON data into the settings variable
-	if err := json.Unmarshal(data, &settings); err != nil {
-		log.Fatal(err)
-	}
 	controlsArt := []string{
 		"000000000 0 0 0 0 0 0 0 0 0 0 0 0           0 00000000000 000000000 0 0 0 0 0 0 0 0 0 0 0 0           000000000",
 		"0         0                   0 0 0         0      0      0       0 0                   0 0           0        ",
@@ -338,11 +287,11 @@ func controls(stdscr *gc.Window) Controls {
 	for i, line := range controlsArt {
 		stdscr.MovePrint(centerY+i, centerX, line)
 	}
-	for i := range buttonControlsArt {
-		arg1, arg2 := settings.Controls.ReturnControlForNumber(i)
+	for i := 0; i < settings.ControlCount; i++ {
+		arg1, arg2 := config.Controls.ReturnControlForNumber(i)
 		for j, line := range buttonControlsArt {
 			if j == 2 {
-				if arg1 == "shoot" && arg2 == " " {
+				if arg2 == " " {
 					arg2 = "space"
 				}
 				stdscr.MovePrintf(centerY+j+10+i*5, centerX+45-len(arg1), strconv.Itoa(i+1)+". "+"%s-|___|-%s", arg1, arg2)
@@ -352,6 +301,7 @@ func controls(stdscr *gc.Window) Controls {
 		}
 	}
 	stdscr.Refresh()
+	changed := false
 	for {
 		stdscr.Timeout(-1)
 
@@ -361,110 +311,84 @@ func controls(stdscr *gc.Window) Controls {
 			break
 		}
 		dataForControl := stdscr.GetChar()
-		control, _ := settings.Controls.ReturnControlForNumber(int(controlNumber) - 49) // Subtract 49 to get the correct index
+		control, old := config.Controls.ReturnControlForNumber(int(controlNumber) - 49) // Subtract 49 to get the correct index
 		if control != "" && dataForControl != 0 {
-			settings.Controls.SetControlForString(string(dataForControl), control)
-			settings.Controls.ChangeControlButtonArtUpdated(string(dataForControl), control, stdscr, centerY, centerX, int(controlNumber)-49)
-
-			// Marshal the updated controls back to JSON
-			newData, err := json.Marshal(settings)
-			if err != nil {
-				log.Fatal(err)
-			}
-
-			// Write the JSON data back to the file
-			if err := os.WriteFile("json/settings.json", newData, 0644); err != nil {
-				log.Fatal(err)
+			config.Controls.SetControlForString(string(rune(dataForControl)), control)
+			if err := config.Controls.Validate(); err != nil {
+				// Keep the old key if the new one is already used by another control
+				config.Controls.SetControlForString(old, control)
+				stdscr.MovePrint(centerY+9, centerX, fmt.Sprintf("%-120s", err.Error()))
+				continue
 			}
+			stdscr.MovePrint(centerY+9, centerX, strings.Repeat(" ", 120))
+			changeControlButtonArtUpdated(&config.Controls, stdscr, centerY, centerX, int(controlNumber)-49)
+			changed = true
+		}
+	}
+	if changed {
+		if err := config.Save(settingsFile); err != nil {
+			log.Println("Saving the settings:", err)
 		}
 	}
 	stdscr.Timeout(0)
-	return settings.Controls
+	return config.Controls
 }
 
-/* A method that Changes the control printed if a control is changed after the controls are printed */
-func (c *Controls) ChangeControlButtonArtUpdated(dataForControl string, control string, stdscr *gc.Window, centerY, centerX int, controlNumber int) {
+/* A function that Changes the control printed if a control is changed after the controls are printed */
+func changeControlButtonArtUpdated(c *settings.Controls, stdscr *gc.Window, centerY, centerX int, controlNumber int) {
 	arg1, arg2 := c.ReturnControlForNumber(controlNumber)
-	if arg1 == "shoot" && arg2 == " " {
+	if arg2 == " " {
 		arg2 = "space"
-	}
-	if arg1 == "shoot" && arg2 != " " {
+	} else {
 		arg2 = arg2 + "    "
 	}
 	stdscr.MovePrintf(centerY+2+10+controlNumber*5, centerX+45-len(arg1), strconv.Itoa(controlNumber+1)+". "+"%s-|___|-%s", arg1, arg2)
 }
 
-/* A method that changes the controls with 'dataForControl' and 'control' which are 'what you want to put for the control' and 'which control'  */
-func (c *Controls) SetControlForString(dataForControl string, control string) {
-	switch control {
-	case "up":
-		{
-			c.Up = dataForControl
-		}
-	case "down":
-		{
-			c.Down = dataForControl
-
-		}
-	case "left":
-		{
-			c.Left = dataForControl
-
-		}
-	case "right":
-		{
-
-			c.Right = dataForControl
-
-		}
-	case "shoot":
-		{
-			c.Shoot = dataForControl
-		}
+/* VolumeStep is how much the volume changes with one press of + or - */
+const VolumeStep = 0.1
+
+/* A function that changes the volume settings with a key from the main menu and saves them */
+func changeVolume(key rune) {
+	audio := mixer.Audio()
+	switch key {
+	case 'm':
+		audio.Muted = !audio.Muted
+	case '+', '=':
+		audio.Volume += VolumeStep
+	case '-':
+		audio.Volume -= VolumeStep
+	}
+	mixer.SetAudio(audio)
+	mixer.Play(sound.MenuMove)
+	saved := mixer.Audio()
+	config.Audio = &saved
+	if err := config.Save(settingsFile); err != nil {
+		log.Println("Saving the volume:", err)
 	}
 }
 
-/* A method that takes a number and returns the data for that number */
-func (c *Controls) ReturnControlForNumber(n int) (string, string) {
-	switch n {
-	case 0:
-		{
-			return "up", c.Up
-		}
-	case 1:
-		{
-			return "down", c.Down
-		}
-	case 2:
-		{
-			return "left", c.Left
-		}
-	case 3:
-		{
-			return "right", c.Right
-		}
-	case 4:
-		{
-			return "shoot", c.Shoot
-		}
-	default:
-		{
-			return "", ""
-		}
+/* A function that returns the volume shown on the main menu */
+func volumeText() string {
+	audio := mixer.Audio()
+	if audio.Muted {
+		return "Volume: muted  (m to unmute)"
 	}
+	return fmt.Sprintf("Volume: %3.0f%%  (+/- to change, m to mute)", audio.Volume*100)
 }
 
 /* A function that prints the game over menu */
-func gameOverMenu(stdscr *gc.Window) bool {
-	lines, cols := stdscr.MaxYX()
-	centerX := (cols + 158) / 2
+func gameOverMenu(stdscr *gc.Window, r render.Renderer, outcome game.Outcome, seed int64) bool {
+	lines, _ := r.Size()
 	centerY := (lines - 40) / 2
-	content, err := readFile("design/death_menu.txt")
+	menu, err := readFile("design/death_menu.txt")
 	if err != nil {
 		log.Fatal(err)
 	}
-	stdscr.MovePrint(centerY, centerX, content)
-	stdscr.Refresh()
+	r.DrawText(centerY, 0, menu, render.Style{})
+	r.DrawText(centerY+20, 66, fmt.Sprintf("%s  Seed: %d", outcome, seed), render.Style{})
+	r.Present()
+	mixer.Play(sound.GameOver)
 	for {
 		input := stdscr.GetChar()
 		switch int(input) {
@@ -480,397 +404,613 @@ func gameOverMenu(stdscr *gc.Window) bool {
 	}
 }
 
+/* A function that prints the level complete menu and returns true if the next level should be played */
+func levelCompleteMenu(stdscr *gc.Window, r render.Renderer, world *game.World, hasNext bool) bool {
+	lines, _ := r.Size()
+	centerY := (lines - 40) / 2
+	menu, err := readFile("design/level_complete_menu.txt")
+	if err != nil {
+		log.Fatal(err)
+	}
+	r.DrawText(centerY, 0, menu, render.Style{})
+	r.DrawText(centerY+18, 66, fmt.Sprintf("Level %d  Score: %d  Enemies destroyed: %d", world.Level.Number, world.Ship.Score, world.Kills), render.Style{})
+	if !hasNext {
+		r.DrawText(centerY+19, 66, "That was the last level!", render.Style{})
+	}
+	r.Present()
+	for {
+		switch stdscr.GetChar() {
+		case '1':
+			if hasNext {
+				return true
+			}
+		case '2':
+			return false
+		}
+	}
+}
+
+/* A function that asks the player for their initials after a new high score and returns them */
+func enterName(stdscr *gc.Window, r render.Renderer, score int) string {
+	lines, cols := r.Size()
+	y, x := lines/2, (cols-44)/2
+	stdscr.Timeout(-1)
+	defer stdscr.Timeout(0)
+	name := ""
+	for {
+		r.DrawText(y, x, "+------------------------------------------+", render.Style{Color: render.Yellow, Bold: true})
+		r.DrawText(y+1, x, fmt.Sprintf("| NEW HIGH SCORE: %-8d Initials: %-3s_ |", score, name), render.Style{Color: render.Yellow, Bold: true})
+		r.DrawText(y+2, x, "+------------------------------------------+", render.Style{Color: render.Yellow, Bold: true})
+		r.Present()
+		switch key := stdscr.GetChar(); {
+		case key == gc.KEY_RETURN || key == gc.KEY_ENTER:
+			if name == "" {
+				name = "???"
+			}
+			return name
+		case key == gc.KEY_BACKSPACE || key == 127 || key == 8:
+			if len(name) > 0 {
+				name = name[:len(name)-1]
+			}
+		case len(name) < 3 && (key >= 'a' && key <= 'z' || key >= 'A' && key <= 'Z' || key >= '0' && key <= '9'):
+			name += strings.ToUpper(string(rune(key)))
+		}
+	}
+}
+
+/* A function that saves a finished run to the high scores if it is a new record */
+func recordHighScore(stdscr *gc.Window, r render.Renderer, highScores *save.HighScores, world *game.World, character game.Character) {
+	if !highScores.Qualifies(world.Level.Number, world.Ship.Score) {
+		return
+	}
+	highScores.Add(save.HighScore{
+		Name:     enterName(stdscr, r, world.Ship.Score),
+		Score:    world.Ship.Score,
+		Level:    world.Level.Number,
+		Ship:     character.Name,
+		Duration: world.Ticks / world.TickRate,
+		Seed:     world.Seed,
+		Date:     time.Now(),
+	})
+	if err := highScores.Save(highScoresFile); err != nil {
+		log.Println("Saving the high scores:", err)
+	}
+}
+
+/* A function that shows the high score table of every level, left and right change the level */
+func highScoresMenu(stdscr *gc.Window, r render.Renderer, levels game.Levels, highScores *save.HighScores) {
+	if len(levels.Levels) == 0 {
+		return
+	}
+	stdscr.Timeout(-1)
+	defer stdscr.Timeout(0)
+	current := 0
+	for {
+		level := levels.Levels[current]
+		r.Clear()
+		r.DrawText(1, 4, fmt.Sprintf("HIGH SCORES - LEVEL %d   (<- -> change level, q back)", level.Number), render.Style{Bold: true})
+		r.DrawText(3, 4, fmt.Sprintf("%-4s %-5s %8s  %-16s %8s  %-20s %s", "#", "NAME", "SCORE", "SHIP", "TIME", "SEED", "DATE"), render.Style{Color: render.Yellow})
+		for i, hs := range highScores.Top(level.Number) {
+			r.DrawText(4+i, 4, fmt.Sprintf("%-4d %-5s %8d  %-16s %7ds  %-20d %s", i+1, hs.Name, hs.Score, hs.Ship, hs.Duration, hs.Seed, hs.Date.Format("2006-01-02")), render.Style{})
+		}
+		if len(highScores.Top(level.Number)) == 0 {
+			r.DrawText(4, 4, "No runs yet", render.Style{})
+		}
+		r.Present()
+
+		switch stdscr.GetChar() {
+		case gc.KEY_RIGHT:
+			if current < len(levels.Levels)-1 {
+				current++
+			}
+		case gc.KEY_LEFT:
+			if current > 0 {
+				current--
+			}
+		case 'q', 27, gc.KEY_RETURN:
+			return
+		}
+	}
+}
+
 /* A function that prints the main menu */
-func showMenu(stdscr *gc.Window) rune {
+func showMenu(stdscr *gc.Window, r render.Renderer) rune {
 	if skipMainMenu {
 		return '1'
 	}
-	stdscr.Clear()
-	stdscr.Erase()
-	stdscr.Refresh()
 
-	leftBullet := newBullet(19, 19, 1)
-	rightBullet := newBullet(19, 123, -1)
-	objects = append(objects, leftBullet)
-	objects = append(objects, rightBullet)
+	lines, _ := r.Size()
+	leftBullet := game.NewBullet(19, 19, 1)
+	rightBullet := game.NewBullet(19, 123, -1)
 	contents, err := readFile("design/main_menu.txt")
 	if err != nil {
 		log.Fatal(err)
 	}
-	stdscr.MovePrint(0, 0, contents)
-	stdscr.Refresh()
-	bulletTicker := time.NewTicker(time.Second / 16)
+	bulletTicker := time.NewTicker(time.Second / game.DefaultTickRate)
+	defer bulletTicker.Stop()
 	for {
 		select {
 		case <-bulletTicker.C:
-			lefty, leftx := leftBullet.YX()
-			righty, rightx := rightBullet.YX()
-			log.Infof("Printing a bullet on the screen: y: %d x: %d", lefty, leftx)
-			stdscr.MovePrint(lefty, leftx, " ")
-			stdscr.MovePrint(righty, rightx, " ")
-			if leftx == rightx {
-				leftBullet.Erase()
-				rightBullet.Erase()
-				leftBullet = newBullet(19, 19, 1)
-				rightBullet = newBullet(19, 123, -1)
-				objects = append(objects, leftBullet)
-				objects = append(objects, rightBullet)
-			}
-			leftBullet.Update()
-			rightBullet.Update()
-			drawObjects(stdscr)
+			log.Infof("Printing a bullet on the screen: y: %d x: %d", leftBullet.Y, leftBullet.X)
+			if leftBullet.X == rightBullet.X {
+				leftBullet = game.NewBullet(19, 19, 1)
+				rightBullet = game.NewBullet(19, 123, -1)
+			}
+			leftBullet.X += leftBullet.DirX
+			rightBullet.X += rightBullet.DirX
+			r.Clear()
+			r.DrawText(0, 0, contents, render.Style{})
+			r.DrawText(lines-1, 0, volumeText(), render.Style{})
+			render.DrawObject(r, leftBullet)
+			render.DrawObject(r, rightBullet)
+			r.Present()
 		default:
 			key := stdscr.GetChar()
 			if key >= '1' && key <= '9' {
-				leftBullet.Erase()
-				rightBullet.Erase()
+				mixer.Play(sound.MenuEnter)
+				return rune(key)
+			}
+			if key == 'm' || key == '+' || key == '=' || key == '-' {
 				return rune(key)
 			}
 		}
 	}
 }
 
-/* A method that handles input for the spaceship */
-func (s *Ship) handleInput(stdscr *gc.Window, settings Settings, character *Character) {
-	lines, cols := stdscr.MaxYX()
-	y, x := s.YX()
-	k := stdscr.GetChar()
-	if settings.Controls.Shoot == "space" {
-		settings.Controls.Shoot = " "
-	}
-	switch byte(k) {
-	case 0:
-		break
-	case byte([]rune(settings.Controls.Left)[0]):
-		x -= character.Attributes.Speed
-		if x < 2 {
-			x = 2
-		}
-	case byte([]rune(settings.Controls.Right)[0]):
-		x += character.Attributes.Speed
-		if x > cols-3 {
-			x = cols - 3
-		}
-	case byte([]rune(settings.Controls.Down)[0]):
-		y += character.Attributes.Speed
-		if y > lines-4 {
-			y = lines - 4
-		}
-	case byte([]rune(settings.Controls.Up)[0]):
-		y -= character.Attributes.Speed
-		if y < 2 {
-			y = 2
-		}
-	case byte([]rune(settings.Controls.Shoot)[0]):
-		objects = append(objects, newBullet(y+1, x+4, 1))
-		objects = append(objects, newBullet(y+3, x+4, 1))
-	default:
-	}
-	s.MoveWindow(y, x)
-	for _, ob := range objects {
-		if b, ok := ob.(*Bullet); ok {
-			bullety, bulletx := b.YX()
-			if bullety >= y && bullety <= y+5 && bulletx >= x && bulletx <= x+6 && b.dirX == -1 {
-				b.handleEnemyBullet(s, y, x)
-				break
-			} else {
-				b.handleSpaceshipBullet(s)
+/* How a run of a level was left */
+type playResult int
+
+const (
+	playOver    playResult = iota // The world is over
+	playRestart                   // The player restarted the level from the pause menu
+	playQuit                      // The player quit to the main menu from the pause menu
+)
+
+/* A function that runs a level with a fixed timestep until the world is over, the input is sampled once per step */
+func playLevel(stdscr *gc.Window, r render.Renderer, field *render.Starfield, world *game.World, controls *settings.Controls, rec *replay.Replay) playResult {
+	clock := game.NewClock(world.TickRate, time.Now())
+	var in game.Input
+	var drawn time.Time
+	for !world.Over() {
+		// Keep what was pressed until a step uses it
+		polled, paused := pollInput(stdscr, *controls)
+		if paused {
+			switch pauseMenu(stdscr, r, field, world, controls) {
+			case pauseRestart:
+				return playRestart
+			case pauseQuit:
+				return playQuit
+			}
+			// Forget the time spent paused so that the world doesn't catch up on it
+			clock.Reset(time.Now())
+			in = game.Input{}
+			continue
+		}
+		in = in.Or(polled)
+		for steps := clock.Advance(time.Now()); steps > 0 && !world.Over(); steps-- {
+			world.Step(in)
+			if rec != nil {
+				rec.Record(in)
 			}
+			in = game.Input{}
+			for _, s := range world.Sounds {
+				mixer.Play(sound.Sound(s))
+			}
+		}
+		// Only draw as many frames as the fps flag allows
+		if now := time.Now(); *fps <= 0 || now.Sub(drawn) >= time.Second/time.Duration(*fps) {
+			render.DrawFrame(r, field, world)
+			drawn = now
 		}
+		time.Sleep(clock.Wait())
 	}
+	return playOver
 }
 
-/* Handles the enemy's bullets */
-func (b *Bullet) handleEnemyBullet(s *Ship, y, x int) {
-	objects = append(objects, newExplosion(y, x))
-	b.alive = false
-	s.life--
-}
-
-/* Handles the spaceship's bullets */
-func (b *Bullet) handleSpaceshipBullet(s *Ship) {
-	bullety, bulletx := b.YX()
-	for _, ob := range objects {
-		if enemy, ok := ob.(*EnemyShip); ok {
-			y, x := enemy.YX()
-			if bullety >= y && bullety <= y+5 && bulletx >= x && bulletx <= x+6 {
-				objects = append(objects, newExplosion(y, x))
-				b.alive = false
-				enemy.Clear()
-				enemy.alive = false
-				s.Score++
-				break
+/* replaySpeeds are the speeds a replay can be watched at */
+var replaySpeeds = []float64{0.25, 0.5, 1, 2, 4, 8}
+
+/* A function that plays back the inputs of a replay in a world, space pauses, + and - change the speed, . steps once while paused and q stops, it returns an error if the world didn't end the same way as the recorded run */
+func watchReplay(stdscr *gc.Window, r render.Renderer, field *render.Starfield, world *game.World, playback *replay.Replay) error {
+	speed := 2
+	paused := false
+	newClock := func() *game.Clock {
+		rate := int(float64(world.TickRate) * replaySpeeds[speed])
+		if rate < 1 {
+			rate = 1
+		}
+		return game.NewClock(rate, time.Now())
+	}
+	clock := newClock()
+	done := func() bool {
+		return world.Over() || world.Ticks >= len(playback.Inputs)
+	}
+	for !done() {
+		steps := 0
+		for k := stdscr.GetChar(); k != 0; k = stdscr.GetChar() {
+			switch k {
+			case ' ', 'p':
+				paused = !paused
+				clock.Reset(time.Now())
+			case '+', '=':
+				if speed < len(replaySpeeds)-1 {
+					speed++
+					clock = newClock()
+				}
+			case '-':
+				if speed > 0 {
+					speed--
+					clock = newClock()
+				}
+			case '.':
+				if paused {
+					steps++
+				}
+			case 'q', 27:
+				return nil
+			}
+		}
+		if !paused {
+			steps = clock.Advance(time.Now())
+		}
+		for ; steps > 0 && !done(); steps-- {
+			world.Step(playback.Inputs[world.Ticks])
+			for _, s := range world.Sounds {
+				mixer.Play(sound.Sound(s))
 			}
 		}
+		render.DrawScene(r, field, world)
+		drawReplayStatus(r, world, playback, replaySpeeds[speed], paused)
+		r.Present()
+		time.Sleep(clock.Wait())
 	}
-}
 
-/* An interface for any object such as the spaceship, the enemies, the stars, the explosion, and the bullets */
-type Object interface {
-	Cleanup()
-	Draw(*gc.Window)
-	Expired(int, int) bool
-	Update()
-}
-
-/* A struct for the bullets */
-type Bullet struct {
-	*gc.Window
-	alive bool
-	dirX  int
-}
-
-/* A function that creates a new bullet */
-func newBullet(y, x int, dirX int) *Bullet {
-	w, err := gc.NewWindow(1, 1, y, x)
+	err := playback.Check(world)
+	text := []string{"Replay finished: " + world.Outcome.String(), "", "Press any key"}
 	if err != nil {
-		log.Println("newBullet:", err)
+		text[0] = "Replay out of sync!"
 	}
-	w.AttrOn(gc.A_BOLD | gc.ColorPair(4))
-	w.Print("-")
-	return &Bullet{w, true, dirX}
-}
-
-/* A function that deletes a bullet */
-func (b *Bullet) Cleanup() {
-	b.Delete()
-}
-
-/* A function that draws a bullet */
-func (b *Bullet) Draw(w *gc.Window) {
-	w.Overlay(b.Window)
-}
-
-/* A function that checks if a bullet has expired/died/offTheScreen */
-func (b *Bullet) Expired(my, mx int) bool {
-	_, x := b.YX()
-	if x >= mx-1 || !b.alive {
-		return true
+	render.DrawScene(r, field, world)
+	render.DrawPanel(r, text, render.Style{Color: render.Yellow, Bold: true})
+	r.Present()
+	for stdscr.GetChar() == 0 {
+		time.Sleep(10 * time.Millisecond)
 	}
-	return false
+	return err
 }
 
-/* A function that updates the bullet */
-func (b *Bullet) Update() {
-	y, x := b.YX()
-	if x == 255 || x == 0 {
-		b.Erase()
-		return
+/* A function that draws how far the replay got and its controls on the last line */
+func drawReplayStatus(r render.Renderer, world *game.World, playback *replay.Replay, speed float64, paused bool) {
+	lines, _ := r.Size()
+	state := fmt.Sprintf("%gx", speed)
+	if paused {
+		state = "paused"
 	}
-	b.MoveWindow(y, x+b.dirX) // Update the bullet's x-coordinate based on direction
+	text := fmt.Sprintf("REPLAY %d/%d %s   space pause  +/- speed  . step  q quit", world.Ticks, len(playback.Inputs), state)
+	r.DrawText(lines-1, 0, text, render.Style{Color: render.Green, Bold: true})
 }
 
-/* A struct for the spaceship */
-type Ship struct {
-	*gc.Window
-	life  int
-	Score int
-}
-
-/* A struct for the Explosions animation */
-type Explosion struct {
-	*gc.Window
-	life int
-}
-
-/* A function that makes a new Explosion animation */
-func newExplosion(y, x int) *Explosion {
-	w, err := gc.NewWindow(4, 8, y-1, x-1)
-	if err != nil {
-		log.Println("newExplosion:", err)
+/* A function that saves the replay of a run that ended */
+func saveReplay(rec *replay.Replay, world *game.World) {
+	if rec == nil {
+		return
 	}
-	w.ColorOn(4)
-	for i, line := range explosion_ascii {
-		w.MovePrint(i, 0, line)
+	rec.Finish(world)
+	if err := rec.Save(*recordFile); err != nil {
+		log.Println("Saving the replay:", err)
 	}
-	w.ColorOff(4)
-	return &Explosion{w, 5}
 }
 
-/* A function that deletes the Explosion animation */
-func (e *Explosion) Cleanup() {
-	e.Delete()
-}
+/* The choices of the pause menu */
+type pauseChoice int
 
-/* An empty function just to make it so that a explosion can fit into the object interface */
-func (e *Explosion) Collide(i int) {}
+const (
+	pauseResume pauseChoice = iota
+	pauseRestart
+	pauseControls
+	pauseQuit
+)
 
-/* A function that draws the Explosion animation */
-func (e *Explosion) Draw(w *gc.Window) {
-	w.Overlay(e.Window)
-}
+/* pauseOptions are the options of the pause menu in the order of the choices */
+var pauseOptions = []string{"Resume", "Restart level", "Controls", "Quit to main menu"}
 
-/* A function that is used just to make it so that a explosion can fit into the object interface and if the explosion animation is gone */
-func (e *Explosion) Expired(y, x int) bool {
-	return e.life <= 0
-}
+/* A function that shows the pause menu over the frozen world until the player resumes, restarts or quits */
+func pauseMenu(stdscr *gc.Window, r render.Renderer, field *render.Starfield, world *game.World, bindings *settings.Controls) pauseChoice {
+	stdscr.Timeout(-1)
+	defer stdscr.Timeout(0)
+	selected := 0
+	for {
+		text := []string{"PAUSED", ""}
+		for i, option := range pauseOptions {
+			marker := "  "
+			if i == selected {
+				marker = "> "
+			}
+			text = append(text, fmt.Sprintf("%s%d. %s", marker, i+1, option))
+		}
+		render.DrawScene(r, field, world)
+		render.DrawPanel(r, text, render.Style{Color: render.Yellow, Bold: true})
+		r.Present()
 
-/* A function that is used just to make it so that a explosion can fit into the object interface */
-func (e *Explosion) Update() {
-	e.life--
+		key := stdscr.GetChar()
+		choice := pauseChoice(-1)
+		switch {
+		case key == gc.KEY_UP || byte(key) == keyFor(bindings.Up):
+			mixer.Play(sound.MenuMove)
+			selected = (selected + len(pauseOptions) - 1) % len(pauseOptions)
+		case key == gc.KEY_DOWN || byte(key) == keyFor(bindings.Down):
+			mixer.Play(sound.MenuMove)
+			selected = (selected + 1) % len(pauseOptions)
+		case key == gc.KEY_RETURN || key == gc.KEY_ENTER:
+			choice = pauseChoice(selected)
+		case key >= '1' && int(key) < '1'+len(pauseOptions):
+			choice = pauseChoice(key - '1')
+		case key == 27 || byte(key) == keyFor(bindings.Pause):
+			choice = pauseResume
+		}
+		if choice >= 0 {
+			mixer.Play(sound.MenuEnter)
+		}
+		switch choice {
+		case pauseControls:
+			*bindings = controls(stdscr)
+			stdscr.Timeout(-1)
+		case pauseResume, pauseRestart, pauseQuit:
+			return choice
+		}
+	}
 }
 
-/* A function that is used to delete the spaceship */
-func (s *Ship) Cleanup() {
-	s.Delete()
+/* A function that reads every key pressed since the last call and returns them as one input, and if the pause key was pressed */
+func pollInput(stdscr *gc.Window, controls settings.Controls) (game.Input, bool) {
+	var in game.Input
+	for k := stdscr.GetChar(); k != 0; k = stdscr.GetChar() {
+		if k == 27 || byte(k) == keyFor(controls.Pause) {
+			return in, true
+		}
+		in = in.Or(inputForKey(k, controls))
+	}
+	return in, false
 }
 
-/* A function that makes the new spaceship */
-func newShip(y, x int, character *Character) *Ship {
-	w, err := gc.NewWindow(5, 7, y, x)
-	if err != nil {
-		log.Fatal("newShip:", err)
-	}
-
-	// Determine the color pair based on the character's color attribute
-	var colorPair gc.Char
-	switch character.Attributes.Color {
-	case "blue":
-		colorPair = gc.ColorPair(5) // Assuming color pair 1 is blue
-	case "red":
-		colorPair = gc.ColorPair(4) // Assuming color pair 2 is red
-	case "green":
-		colorPair = gc.ColorPair(6) // Assuming color pair 3 is green
-	default:
-		colorPair = gc.ColorPair(0) // Default to the default color pair (usually white text on black background)
+/* A function that turns a key into the input for the spaceship using the controls */
+func inputForKey(k gc.Key, controls settings.Controls) game.Input {
+	if k == 0 {
+		return game.Input{}
 	}
-
-	// Set the color pair for the ship's window
-	w.AttrOn(colorPair)
-
-	for i := 0; i < len(ship_ascii); i++ {
-		w.MovePrint(i, 0, ship_ascii[i])
+	key := byte(k)
+	return game.Input{
+		Up:        key == keyFor(controls.Up),
+		Down:      key == keyFor(controls.Down),
+		Left:      key == keyFor(controls.Left),
+		Right:     key == keyFor(controls.Right),
+		Shoot:     key == keyFor(controls.Shoot),
+		Cycle:     key == keyFor(controls.Cycle),
+		Secondary: key == keyFor(controls.Secondary),
 	}
+}
 
-	// Turn off color pair to avoid affecting subsequent output
-	w.AttrOff(colorPair)
-	if character.Attributes.Damage == 0 {
-		character.Attributes.Damage = 5
+/* A function that returns the key of a control, 0 if the control is not set so that it never matches */
+func keyFor(control string) byte {
+	if control == "space" {
+		return ' '
 	}
-	if character.Attributes.Speed == 0 {
-		character.Attributes.Speed = 1
+	if control == "" {
+		return 0
 	}
-
-	return &Ship{w, character.Attributes.Damage, 0}
+	return byte([]rune(control)[0])
 }
 
-/* A function that reads a file and returns the contents and an error if there is one while reading a file */
+/* A function that reads a file of the content and returns the contents and an error if there is one while reading a file */
 func readFile(filename string) (string, error) {
-	content, err := os.ReadFile(filename)
-	if err != nil {
-		return "", err
-	}
-	return string(content), nil
+	data, err := fs.ReadFile(content, filename)
+	return string(data), err
 }
 
-/* A function that draws the spaceship */
-func (s *Ship) Draw(w *gc.Window) {
-	w.Overlay(s.Window)
+/* A function that loads the settings from the config directory, or the shipped settings if the player never saved any */
+func loadConfig() (settings.Settings, error) {
+	if _, err := os.Stat(settingsFile); err == nil {
+		return settings.Load(settingsFile)
+	}
+	data, err := fs.ReadFile(library, "json/settings.json")
+	if err != nil {
+		return settings.Defaults(), err
+	}
+	return settings.Parse(data)
 }
 
-/* A function that checks if the spaceship has died */
-func (s *Ship) Expired(y, x int) bool {
-	return s.life <= 0
+/* A struct for everything loaded from the content, it changes when another content pack is chosen */
+type Content struct {
+	Levels     game.Levels
+	Enemies    game.EnemyTypes
+	Boss       game.BossDesign
+	Campaign   *save.Campaign
+	HighScores *save.HighScores
 }
 
-/* A function just to make it so that the spaceship can be part of the object */
-func (s *Ship) Update() {}
-
-/* A struct for the ememies spaceships */
-type EnemyShip struct {
-	*gc.Window
-	alive        bool
-	shootTicker  *time.Ticker // Ticker to control enemy ship shooting
-	bulletSymbol string       // Symbol for enemy ship bullets
-	bulletDirX   int          // X-direction for enemy ship bullets (-1 for left, 1 for right)
-}
+/* A function that lays the content pack with a name over the library and loads the content from it, every pack has its own campaign and high scores */
+func loadContent(packs []*mods.Pack, name string) (*Content, error) {
+	fsys := fs.FS(library)
+	saveName := "save.json"
+	highScoresName := "highscores.json"
+	if name != "" {
+		pack, ok := mods.Find(packs, name)
+		if !ok {
+			return nil, fmt.Errorf("there is no content pack named %q", name)
+		}
+		fsys = mods.NewOverlay(library, pack)
+		slug := packSlug(name)
+		saveName = "save-" + slug + ".json"
+		highScoresName = "highscores-" + slug + ".json"
+	}
 
-/* A function that makes a new enemy ship */
-func newEnemyShip(y, x int) *EnemyShip {
-	w, err := gc.NewWindow(5, 7, y, x)
-	if err != nil {
-		log.Fatal("newEnemyShip:", err)
+	c := &Content{}
+	var err error
+	if c.Levels, err = game.LoadLevels(fsys, "json/levels.json"); err != nil {
+		return nil, err
 	}
-	for i := 0; i < len(enemy_ascii); i++ {
-		w.MovePrint(i, 0, enemy_ascii[i])
+	if len(c.Levels.Levels) == 0 {
+		return nil, errors.New("there are no levels")
 	}
-	// Create a ticker for enemy ship shooting
-	shootTicker := time.NewTicker(time.Second * 2)
-	return &EnemyShip{w, true, shootTicker, "-", -1}
-}
-
-/* A function that deletes the enemy ship */
-func (e *EnemyShip) Cleanup() {
-	e.Delete()
+	if c.Enemies, err = game.LoadEnemies(fsys, "json/enemies.json"); err != nil {
+		return nil, err
+	}
+	if _, err := game.LoadCharacters(fsys, "json/characters.json"); err != nil {
+		return nil, err
+	}
+	if c.Boss, err = game.LoadBossDesign(fsys, "design/spaceship_boss_fight_minions.txt"); err != nil {
+		log.Println("Loading the boss, using the default one:", err)
+		c.Boss = game.DefaultBossDesign
+	}
+	if campaignFile, err = library.UserFile(saveName); err != nil {
+		return nil, err
+	}
+	if highScoresFile, err = library.UserFile(highScoresName); err != nil {
+		return nil, err
+	}
+	if c.Campaign, err = save.LoadCampaign(campaignFile, c.Levels); err != nil {
+		return nil, err
+	}
+	if c.HighScores, err = save.LoadHighScores(highScoresFile); err != nil {
+		return nil, err
+	}
+	content = fsys
+	return c, nil
 }
 
-/* A function that draws the ememy ship */
-func (e *EnemyShip) Draw(w *gc.Window) {
-	w.Overlay(e.Window)
+/* A function that turns the name of a content pack into something that can be used in a file name */
+func packSlug(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' {
+			return r
+		}
+		return '_'
+	}, name)
 }
 
-/* A function that checks if the ememy ship has expired/died/goneOffTheScreen */
-func (e *EnemyShip) Expired(my, mx int) bool {
-	_, x := e.YX()
-	if x <= mx-1 || !e.alive {
-		return true
+/* A function that shows the content packs that were found and returns the name of the chosen one, "" for the base content */
+func packsMenu(stdscr *gc.Window, r render.Renderer, packs []*mods.Pack, current string) string {
+	stdscr.Timeout(-1)
+	defer stdscr.Timeout(0)
+	selected := 0
+	for i, pack := range packs {
+		if pack.Name == current {
+			selected = i + 1
+		}
 	}
-	return false
-}
-
-/* A function that updates a enemy ship */
-func (e *EnemyShip) Update() {
-	y, x := e.YX()
-	e.MoveWindow(y, x-1)
+	for {
+		r.Clear()
+		r.DrawText(1, 4, "CONTENT PACKS   (up/down choose, enter play, q back)", render.Style{Bold: true})
+		for i := 0; i <= len(packs); i++ {
+			text := "Base game"
+			if i > 0 {
+				pack := packs[i-1]
+				text = fmt.Sprintf("%s %s by %s - %s", pack.Name, pack.Version, pack.Author, pack.Description)
+			}
+			style := render.Style{}
+			marker := "  "
+			if i == selected {
+				style.Color = render.Yellow
+				marker = "> "
+			}
+			r.DrawText(3+i, 4, marker+text, style)
+		}
+		if len(packs) == 0 {
+			r.DrawText(5, 4, "No content packs found, put them in a mods directory:", render.Style{})
+			for i, dir := range library.Subdirs("mods") {
+				r.DrawText(6+i, 6, dir, render.Style{})
+			}
+		}
+		r.Present()
 
-	select {
-	case <-e.shootTicker.C:
-		// Create bullets for enemy ships when they shoot, but in the opposite direction
-		objects = append(objects, newBullet(y+1, x-1, e.bulletDirX)) // Adjust the direction here
-		objects = append(objects, newBullet(y+3, x-1, e.bulletDirX)) // Adjust the direction here
-	default:
-		// Do nothing if it's not time to shoot
+		switch stdscr.GetChar() {
+		case gc.KEY_UP:
+			if selected > 0 {
+				selected--
+				mixer.Play(sound.MenuMove)
+			}
+		case gc.KEY_DOWN:
+			if selected < len(packs) {
+				selected++
+				mixer.Play(sound.MenuMove)
+			}
+		case gc.KEY_RETURN, gc.KEY_ENTER:
+			mixer.Play(sound.MenuEnter)
+			if selected == 0 {
+				return ""
+			}
+			return packs[selected-1].Name
+		case 'q', 27:
+			return current
+		}
 	}
 }
 
-/* A variable for all objects */
-var objects = make([]Object, 0, 16)
-
-/* A function used to update objects */
-func updateObjects(my, mx int) {
-	end := len(objects)
-	tmp := make([]Object, 0, end)
-	for _, ob := range objects {
-		ob.Update()
+/* A function that returns the character with a name from the content, the empty character if the name is empty */
+func findShip(name string) (game.Character, error) {
+	if name == "" {
+		return game.Character{}, nil
 	}
-	for _, ob := range objects {
-		if ob.Expired(my, mx) {
-			ob.Cleanup()
-		} else {
-			tmp = append(tmp, ob)
-		}
+	characters, err := game.LoadCharacters(content, "json/characters.json")
+	if err != nil {
+		return game.Character{}, err
 	}
-	if len(objects) > end {
-		objects = append(tmp, objects[end:]...)
-	} else {
-		objects = tmp
+	var names []string
+	for _, c := range characters.Characters {
+		if strings.EqualFold(c.Name, name) {
+			return c, nil
+		}
+		names = append(names, c.Name)
 	}
+	return game.Character{}, fmt.Errorf("there is no spaceship named %q, the spaceships are: %s", name, strings.Join(names, ", "))
 }
 
-/* A function used to draw objects  */
-func drawObjects(s *gc.Window) {
-	for _, ob := range objects {
-		ob.Draw(s)
+/* A function that returns the index of the level with a number */
+func findLevel(levels game.Levels, number int) (int, bool) {
+	for i, l := range levels.Levels {
+		if l.Number == number {
+			return i, true
+		}
 	}
+	return 0, false
 }
 
-/* A function that takes a number and returns a string of '*' as hearts */
-func lifeToText(n int) string {
-	s := ""
-	for i := 0; i < n; i++ {
-		s += "*"
+/* A function that prints how to use the command */
+func usage() {
+	out := flag.CommandLine.Output()
+	fmt.Fprintf(out, "Usage:\n  space-glide [flags]\n  space-glide [flags] validate [path]\n  space-glide [flags] replay FILE\n\nFlags:\n")
+	flag.PrintDefaults()
+}
+
+/* A function that checks the content in path, a content pack or a directory laid over the data directories or all of the content if it is empty, prints every problem and returns the exit code */
+func validateContent(path string) int {
+	library = assets.New(*dataDir, spaceglide.Content)
+	var problems []validate.Problem
+	where := library.Where
+	switch pack, err := mods.Open(path); {
+	case path == "":
+		problems = validate.Check(library, validate.Options{})
+	case err == nil:
+		problems = validate.Check(pack.FS, validate.Options{Partial: true, Base: library, Replace: pack.Replace})
+		where = func(name string) string { return filepath.Join(path, name) }
+	default:
+		if info, statErr := os.Stat(path); statErr != nil || !info.IsDir() {
+			fmt.Fprintf(os.Stderr, "%s is not a content pack or a directory: %v\n", path, err)
+			return 2
+		}
+		problems = validate.Check(os.DirFS(path), validate.Options{Partial: true, Base: library})
+		where = func(name string) string { return filepath.Join(path, name) }
+	}
+	for _, p := range problems {
+		p.File = where(p.File)
+		fmt.Println(p)
 	}
-	return s
+	if len(problems) > 0 {
+		fmt.Fprintf(os.Stderr, "%d problems found\n", len(problems))
+		return 1
+	}
+	fmt.Println("The content is valid")
+	return 0
 }
 
 func signalHandler(signals chan os.Signal) {
@@ -881,13 +1021,107 @@ func signalHandler(signals chan os.Signal) {
 
 /* The main function where everything starts */
 func main() {
+	flag.Usage = usage
+	flag.Parse()
+	if flag.Arg(0) == "validate" {
+		os.Exit(validateContent(flag.Arg(1)))
+	}
+	var playback *replay.Replay
+	if flag.Arg(0) == "replay" {
+		var err error
+		if playback, err = replay.Load(flag.Arg(1)); err != nil {
+			fmt.Fprintln(os.Stderr, "Loading the replay:", err)
+			os.Exit(2)
+		}
+		*seed = playback.Seed
+		*tickRate = playback.TickRate
+		*shipName = playback.Ship
+		*startLevel = playback.Level
+	}
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+	}
+
 	// Logging
-	logFile, err := os.OpenFile("/tmp/space-glide.log", os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
+	logFile, err := os.OpenFile(*logFileName, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
 	if err != nil {
-		print("could not open log file")
+		fmt.Fprintln(os.Stderr, "could not open log file:", err)
 		os.Exit(1)
 	}
 	log.SetOutput(logFile)
+	log.Infof("Seed: %d", *seed)
+
+	// Content is searched in the data directories and saves go to the user directories
+	library = assets.New(*dataDir, spaceglide.Content)
+	log.Infof("Content directories: %s", library)
+	// Broken content is reported before curses takes over the terminal
+	if problems := validate.Check(library, validate.Options{}); len(problems) > 0 {
+		for _, p := range problems {
+			p.File = library.Where(p.File)
+			fmt.Fprintln(os.Stderr, p)
+		}
+		fmt.Fprintln(os.Stderr, "The content has problems, see space-glide validate")
+		os.Exit(1)
+	}
+	if settingsFile, err = library.ConfigFile("settings.json"); err != nil {
+		log.Fatal(err)
+	}
+	if *recordFile == "" {
+		if *recordFile, err = library.UserFile("last.replay"); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	// Settings, the defaults are used for everything that can't be loaded
+	config, err = loadConfig()
+	if err != nil {
+		log.Println("Loading the settings:", err)
+	}
+
+	level := game.Level{}
+	packs, errs := mods.Discover(library.Subdirs("mods"))
+	for _, err := range errs {
+		log.Println("Loading the content packs:", err)
+	}
+	pack := config.ContentPack
+	if playback != nil {
+		pack = playback.Pack
+	}
+	loaded, err := loadContent(packs, pack)
+	if err != nil && playback != nil {
+		fmt.Fprintln(os.Stderr, "The replay can't be played:", err)
+		os.Exit(1)
+	}
+	if err != nil {
+		log.Printf("Loading the content pack %q, using the base content: %v", config.ContentPack, err)
+		if loaded, err = loadContent(packs, ""); err != nil {
+			log.Fatal(err)
+		}
+	}
+	// A replay is only played with the content it was recorded with
+	if playback != nil {
+		if err := playback.Verify(content); err != nil {
+			fmt.Fprintln(os.Stderr, "The replay can't be played:", err)
+			os.Exit(1)
+		}
+	}
+
+	// The flags can start the game straight in a level with a spaceship
+	character, err := findShip(*shipName)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	// A replay always starts in its level, even one numbered 0 by content that doesn't validate
+	if *startLevel != 0 || playback != nil {
+		i, ok := findLevel(loaded.Levels, *startLevel)
+		if !ok {
+			fmt.Fprintf(os.Stderr, "there is no level %d\n", *startLevel)
+			os.Exit(2)
+		}
+		numberOfLevel = i + 1
+		skipMainMenu = true
+	}
 
 	var stdscr *gc.Window
 	stdscr, err = gc.Init()
@@ -907,88 +1141,132 @@ func main() {
 	stdscr.Keypad(true)
 	stdscr.Timeout(0)
 
-	lines, cols := stdscr.MaxYX()
-	pl, pc := lines, cols*3
-	field := genStarfield(pl, pc)
-
-	character := Character{}
-	settings := Settings{}
-	settings.Controls = NewControls()
-	level := Level{}
+	r := render.NewCurses(stdscr)
+	lines, cols := r.Size()
 
-	gc.InitPair(1, gc.C_WHITE, gc.C_BLACK)
-	gc.InitPair(2, gc.C_YELLOW, gc.C_BLACK)
-	gc.InitPair(3, gc.C_MAGENTA, gc.C_BLACK)
-	gc.InitPair(4, gc.C_RED, gc.C_BLACK)
-
-	gc.InitPair(5, gc.C_BLUE, gc.C_BLACK)
-	gc.InitPair(6, gc.C_GREEN, gc.C_BLACK)
+	// Audio, the game goes on silently if the backend can't be opened
+	mixer.SetAudio(*config.Audio)
+	// The sounds come from the content so that a pack can replace them
+	if err := mixer.LoadDefaults(content, "audio"); err != nil {
+		log.Println("Loading the sounds:", err)
+	}
+	if *noAudio {
+		*audioBackend = sound.BackendNull
+	}
+	backend, err := sound.Open(*audioBackend, mixer, *audioFile)
+	if err != nil {
+		log.Printf("Opening the %s audio backend, playing without sound: %v", *audioBackend, err)
+		backend = sound.NewNull(mixer)
+	}
+	log.Infof("Audio backend: %s", backend.Name())
+	defer backend.Close()
+
+	field := render.NewStarfield(lines, cols*3, rand.New(rand.NewSource(*seed)))
+
+	// Watch a replay in a world the same size as the one it was recorded in
+	if playback != nil {
+		world := game.NewWorld(game.Options{
+			Lines:     playback.Lines,
+			Cols:      playback.Cols,
+			Character: &character,
+			Level:     loaded.Levels.Levels[numberOfLevel-1],
+			Enemies:   loaded.Enemies,
+			Seed:      playback.Seed,
+			TickRate:  playback.TickRate,
+			Boss:      &loaded.Boss,
+		})
+		if err := watchReplay(stdscr, r, field, world, playback); err != nil {
+			backend.Close()
+			gc.End()
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
 
 	stdscr.Clear()
 	for {
-		key := showMenu(stdscr)
+		key := showMenu(stdscr, r)
+		if key == 'm' || key == '+' || key == '=' || key == '-' {
+			changeVolume(key)
+			continue
+		}
 		if key == '2' {
-			character = changeShip(stdscr)
+			character = changeShip(stdscr, character)
 		}
 		if key == '3' {
-			settings.Controls = controls(stdscr)
+			config.Controls = controls(stdscr)
 		}
 		if key == '4' {
+			highScoresMenu(stdscr, r, loaded.Levels, loaded.HighScores)
+		}
+		if key == '5' {
+			name := packsMenu(stdscr, r, packs, config.ContentPack)
+			if next, err := loadContent(packs, name); err != nil {
+				log.Printf("Loading the content pack %q: %v", name, err)
+			} else {
+				loaded = next
+				config.ContentPack = name
+				if err := mixer.LoadDefaults(content, "audio"); err != nil {
+					log.Println("Loading the sounds:", err)
+				}
+				if err := config.Save(settingsFile); err != nil {
+					log.Println("Saving the settings:", err)
+				}
+			}
+			stdscr.Clear()
+		}
+		if key == '6' {
 			break
 		} else if key != '1' {
 			continue
 		}
 		if key == '1' {
-			level = SelectLevel(stdscr)
-		}
-
-		lines, cols := stdscr.MaxYX()
-
-		ship := newShip(lines/2, 5, &character)
-		objects = append(objects, ship)
-
-		text := stdscr.Duplicate()
-
-		c := time.NewTicker(time.Second / 16)
-		c2 := time.NewTicker(time.Second / 16)
-		px := 0
-
-		enemyTicker := time.NewTicker(time.Second * 2) // Create a ticker for spawning enemy ships
-		timeLeftTicker := time.NewTicker(time.Second)  // Create a ticker for spawning enemy ships
-
-	loop:
-		for {
-			text.Erase()
-			stdscr.Erase()
-			text.MovePrintf(0, 0, "Life: [%-"+strconv.Itoa(character.Attributes.Damage)+"s]", lifeToText(ship.life))
-			text.MovePrintf(0, 20, "Score: %d", ship.Score)
-			text.MovePrintf(0, 40, "TimeLeft: %ds", level.Time)
-			stdscr.Copy(field.Window, 0, px, 0, 0, lines-1, cols-1, true)
-			drawObjects(stdscr)
-			stdscr.Overlay(text)
-			stdscr.Refresh()
-			ship.handleInput(stdscr, settings, &character)
-			select {
-			case <-c.C:
-				if px+cols >= pc {
-					break loop
-				}
-				px++
-			case <-c2.C:
-				updateObjects(lines, cols)
-				drawObjects(stdscr)
-			case <-enemyTicker.C: // Spawn enemy ships periodically
-				ey := rand.Intn(lines-4) + 2 // Randomly select the y position for the enemy ship
-				ex := cols - 10              // Set the x position to the right edge of the screen
-				objects = append(objects, newEnemyShip(ey, ex))
-			case <-timeLeftTicker.C:
-				level.Time -= 1
-			default:
-				if ship.Expired(-1, -1) {
-					break loop
-				}
+			level = SelectLevel(stdscr, r, loaded.Levels, loaded.Campaign)
+		}
+
+		var world *game.World
+		result := playRestart
+		for result == playRestart {
+			world = game.NewWorld(game.Options{
+				Lines:     lines,
+				Cols:      cols,
+				Character: &character,
+				Level:     level,
+				Enemies:   loaded.Enemies,
+				Seed:      *seed,
+				TickRate:  *tickRate,
+				Boss:      &loaded.Boss,
+			})
+			rec, err := replay.New(world, character.Name, config.ContentPack, content)
+			if err != nil {
+				log.Println("Recording the run:", err)
+			}
+			result = playLevel(stdscr, r, field, world, &config.Controls, rec)
+			saveReplay(rec, world)
+		}
+		if result == playQuit {
+			skipMainMenu = false
+			stdscr.Clear()
+			continue
+		}
+		if world.Outcome == game.Victory {
+			loaded.Campaign.Complete(loaded.Levels, numberOfLevel-1, world.Ship.Score)
+		} else {
+			loaded.Campaign.Record(level, world.Ship.Score)
+		}
+		if err := loaded.Campaign.Save(campaignFile); err != nil {
+			log.Println("Saving the campaign:", err)
+		}
+		recordHighScore(stdscr, r, loaded.HighScores, world, character)
+		if world.Outcome == game.Victory {
+			// Play the next level entry straight away
+			skipMainMenu = levelCompleteMenu(stdscr, r, world, numberOfLevel < len(loaded.Levels.Levels))
+			if skipMainMenu {
+				numberOfLevel++
 			}
+			continue
 		}
-		skipMainMenu = gameOverMenu(stdscr)
+		skipMainMenu = gameOverMenu(stdscr, r, world.Outcome, world.Seed)
 	}
 }
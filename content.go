@@ -0,0 +1,9 @@
+/* Package spaceglide holds the content shipped with space-glide so that the game works without the json, design and audio directories next to it */
+package spaceglide
+
+import "embed"
+
+/* Content is the shipped json, design and audio files, the data directories are searched before it */
+//
+//go:embed json/characters.json json/enemies.json json/levels.json json/settings.json design audio
+var Content embed.FS
@@ -0,0 +1,191 @@
+package game
+
+import (
+	"io/fs"
+	"regexp"
+	"strings"
+)
+
+/* A struct for one frame of an animation */
+type Frame struct {
+	Art     []string // The ascii art of the frame
+	Seconds float64  // How long the frame is shown
+	Event   string   // Something that happens when the frame starts like "bullet" or "explosion", empty for nothing
+	Line    int      // The line of the "Frame N:" header in the design file, 0 if it wasn't read from one
+}
+
+/* A struct for an animation made of frames */
+type Animation struct {
+	Name   string // The label of the animation from its "Animation: Name" line in the design file, empty if it has none
+	Frames []Frame
+	Loop   bool // If the animation starts again after the last frame, otherwise it stays done on the last frame
+	Line   int  // The line of the label or of the first frame in the design file, 0 if it wasn't read from one
+}
+
+/* A function that makes an animation where every frame is shown for the same time */
+func NewAnimation(frames [][]string, seconds float64, loop bool) *Animation {
+	a := &Animation{Loop: loop}
+	for _, art := range frames {
+		a.Frames = append(a.Frames, Frame{Art: art, Seconds: seconds})
+	}
+	return a
+}
+
+/* A struct that plays an animation one tick at a time */
+type Animator struct {
+	Animation *Animation
+	Frame     int  // The index of the frame being shown
+	Done      bool // If a one-shot animation has finished
+	elapsed   int  // Ticks the frame has been shown for
+}
+
+/* A function that makes an animator that starts at the first frame of an animation */
+func NewAnimator(a *Animation) *Animator {
+	return &Animator{Animation: a}
+}
+
+/* A method that returns the ascii art of the frame being shown */
+func (a *Animator) Art() []string {
+	return a.Animation.Frames[a.Frame].Art
+}
+
+/* A method that advances the animation by one tick and returns the event of the frame it moved to, if there is one */
+func (a *Animator) Update(tickRate int) string {
+	if a.Done {
+		return ""
+	}
+	a.elapsed++
+	if float64(a.elapsed) < a.Animation.Frames[a.Frame].Seconds*float64(tickRate) {
+		return ""
+	}
+	a.elapsed = 0
+	if a.Frame == len(a.Animation.Frames)-1 {
+		if !a.Animation.Loop {
+			a.Done = true
+			return ""
+		}
+		a.Frame = 0
+	} else {
+		a.Frame++
+	}
+	return a.Animation.Frames[a.Frame].Event
+}
+
+/* The events the frames of the animations can have */
+const (
+	EventBullet    = "bullet"    // The bullet of an attack is flying
+	EventExplosion = "explosion" // The bullet of an attack blows up where it hits
+)
+
+/* frameHeader matches lines like "Frame 2:" or "Frame n+1 (Explosion):" in the design files */
+var frameHeader = regexp.MustCompile(`^Frame ([^ (]+)(?: \((.*)\))?:$`)
+
+/* animationLabel matches lines like "Animation: Minion attack" outside of the frames that name the animation after them */
+var animationLabel = regexp.MustCompile(`^Animation: (.+)$`)
+
+/* frameEvent matches lines like "Bullet: ------>*" inside a frame which are events and not art */
+var frameEvent = regexp.MustCompile(`^([A-Za-z]+):`)
+
+/* A function that reads the "Frame N:" blocks of a design file in fsys as animations, every "Frame 1:" starts a new animation */
+func LoadAnimations(fsys fs.FS, filename string, seconds float64, loop bool) ([]*Animation, error) {
+	content, err := fs.ReadFile(fsys, filename)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAnimations(string(content), seconds, loop), nil
+}
+
+/* A function that parses the "Frame N:" blocks of the contents of a design file as animations, an "Animation: Name" line names the next animation */
+func ParseAnimations(content string, seconds float64, loop bool) []*Animation {
+	var anims []*Animation
+	var frame *Frame
+	label, labelLine := "", 0
+	for i, line := range strings.Split(strings.ReplaceAll(content, "\r", ""), "\n") {
+		line = strings.TrimRight(line, " \t")
+		if m := frameHeader.FindStringSubmatch(line); m != nil {
+			if m[1] == "1" || len(anims) == 0 {
+				if label == "" {
+					labelLine = i + 1
+				}
+				anims = append(anims, &Animation{Name: label, Loop: loop, Line: labelLine})
+				label = ""
+			}
+			a := anims[len(anims)-1]
+			a.Frames = append(a.Frames, Frame{Seconds: seconds, Event: eventName(m[2]), Line: i + 1})
+			frame = &a.Frames[len(a.Frames)-1]
+			continue
+		}
+		if frame == nil {
+			if m := animationLabel.FindStringSubmatch(line); m != nil {
+				label, labelLine = m[1], i+1
+			}
+			continue
+		}
+		if line == "" {
+			frame = nil
+			continue
+		}
+		if m := frameEvent.FindStringSubmatch(line); m != nil {
+			frame.Event = eventName(m[1])
+			continue
+		}
+		frame.Art = append(frame.Art, line)
+	}
+	for _, a := range anims {
+		a.trim()
+	}
+	return anims
+}
+
+/* A function that turns the label of a frame like "Bullet reaches" into the event the game knows like "bullet", the first word in lower case */
+func eventName(label string) string {
+	words := strings.Fields(strings.ToLower(label))
+	if len(words) == 0 {
+		return ""
+	}
+	return words[0]
+}
+
+/* A method that checks if a frame of the animation has an event */
+func (a *Animation) HasEvent(event string) bool {
+	for _, f := range a.Frames {
+		if f.Event == event {
+			return true
+		}
+	}
+	return false
+}
+
+/* A function that returns the animation with a label, the labels are compared without caring about case */
+func FindAnimation(anims []*Animation, name string) (*Animation, bool) {
+	for _, a := range anims {
+		if strings.EqualFold(a.Name, name) {
+			return a, true
+		}
+	}
+	return nil, false
+}
+
+/* A method that sets how many seconds every frame of the animation is shown */
+func (a *Animation) setSeconds(seconds float64) {
+	for i := range a.Frames {
+		a.Frames[i].Seconds = seconds
+	}
+}
+
+/* A method that removes the indentation all of the frames have in common so they stay lined up */
+func (a *Animation) trim() {
+	var all []string
+	for _, f := range a.Frames {
+		all = append(all, f.Art...)
+	}
+	if len(all) == 0 {
+		return
+	}
+	indent := len(all[0]) - len(TrimArt(all)[0])
+	for i := range a.Frames {
+		for j, line := range a.Frames[i].Art {
+			a.Frames[i].Art[j] = line[indent:]
+		}
+	}
+}
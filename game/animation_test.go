@@ -0,0 +1,137 @@
+package game
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestParseAnimationsEvents(t *testing.T) {
+	anims := ParseAnimations("Frame 1:\n <>\nBullet: -->*\n\nFrame n (Bullet reaches):\n <>\n\nFrame n+1 (Explosion):\n **\n", 0.1, false)
+	if len(anims) != 1 {
+		t.Fatalf("got %d animations, want 1", len(anims))
+	}
+	var events []string
+	for _, f := range anims[0].Frames {
+		events = append(events, f.Event)
+	}
+	if want := []string{"bullet", "bullet", "explosion"}; !reflect.DeepEqual(events, want) {
+		t.Errorf("events are %q, want %q", events, want)
+	}
+}
+
+func TestParseAnimationsLabels(t *testing.T) {
+	anims := ParseAnimations("Animation: Thruster\n\nFrame 1:\n-\n\nFrame 2:\n=\n\nAnimation: Explosion\nFrame 1:\n**\n", 0.1, true)
+	thruster, ok := FindAnimation(anims, "thruster")
+	if !ok || len(thruster.Frames) != 2 {
+		t.Fatalf("got the thruster %v, want the first animation with 2 frames", thruster)
+	}
+	explosion, ok := FindAnimation(anims, AnimationExplosion)
+	if !ok || explosion.Frames[0].Art[0] != "**" {
+		t.Errorf("got the explosion %v, want the second animation", explosion)
+	}
+	if _, ok := FindAnimation(anims, AnimationBoss); ok {
+		t.Errorf("found a boss animation that isn't in the design")
+	}
+}
+
+func TestLoadBossDesignAnimations(t *testing.T) {
+	design, err := LoadBossDesign(os.DirFS(".."), "design/spaceship_boss_fight_minions.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if design.MinionAttack == nil || design.Explosion == nil || design.Thruster == nil || design.Animation == nil {
+		t.Fatalf("attack %v, explosion %v, thruster %v and boss %v, want all of them loaded", design.MinionAttack, design.Explosion, design.Thruster, design.Animation)
+	}
+	if len(design.MinionFrames) != 11 {
+		t.Errorf("the minion has %d frames, want 11", len(design.MinionFrames))
+	}
+	attack := design.MinionAttack.Frames
+	if attack[0].Event != "bullet" || attack[len(attack)-1].Event != "explosion" || design.MinionAttack.Loop {
+		t.Errorf("the attack starts with %q and ends with %q, want a one-shot bullet to explosion animation", attack[0].Event, attack[len(attack)-1].Event)
+	}
+	if len(design.Explosion.Frames) != 4 || design.Explosion.Loop {
+		t.Errorf("the explosion has %d frames and loop is %v, want 4 one-shot frames", len(design.Explosion.Frames), design.Explosion.Loop)
+	}
+	if len(design.Thruster.Frames) != 4 || !design.Thruster.Loop {
+		t.Errorf("the thruster has %d frames and loop is %v, want 4 looping frames", len(design.Thruster.Frames), design.Thruster.Loop)
+	}
+	if !reflect.DeepEqual(design.Animation.Frames[0].Art, design.Art) {
+		t.Errorf("the first frame of the boss is\n%q\nwant its ascii art\n%q", design.Animation.Frames[0].Art, design.Art)
+	}
+}
+
+/* A function that counts the objects of a type in a world */
+func count[T Object](w *World) int {
+	n := 0
+	for _, ob := range w.Objects {
+		if _, ok := ob.(T); ok {
+			n++
+		}
+	}
+	return n
+}
+
+func TestEnemyAttackShootsOnce(t *testing.T) {
+	design, err := LoadBossDesign(os.DirFS(".."), "design/spaceship_boss_fight_minions.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := testWorld(Level{Time: 100, Enemies: 5, Waves: noSpawns})
+	minion := &EnemyType{Name: "minion", AsciiArt: design.Minion, Health: 1, FireRate: 1, BulletPattern: "single", Movement: "straight", Attack: design.MinionAttack}
+	e := NewEnemyShip(2, 80, minion, w.TickRate)
+	w.Spawn(e)
+	stepN(w, w.TickRate)
+	if e.anim == nil || e.anim.Animation != design.MinionAttack {
+		t.Fatalf("the enemy isn't playing its attack animation")
+	}
+	// Play the whole attack, it takes less than a second
+	stepN(w, w.TickRate-1)
+	if n := count[*Bullet](w); n != 1 {
+		t.Errorf("%d bullets were shot, want one for the whole attack", n)
+	}
+	if n := count[*Explosion](w); n != 0 {
+		t.Errorf("%d explosions, want none while the bullet hasn't hit anything", n)
+	}
+}
+
+func TestBlastBulletExplodesWhereItHits(t *testing.T) {
+	w := testWorld(Level{Time: 100, Enemies: 5, Waves: noSpawns})
+	box := w.Ship.Hitbox()
+	b := NewBullet(box.Y+2, box.X+box.W+3, -1)
+	b.Blast = true
+	w.Spawn(b)
+	stepN(w, 5)
+
+	var blasts []*Explosion
+	for _, ob := range w.Objects {
+		if e, ok := ob.(*Explosion); ok {
+			blasts = append(blasts, e)
+		}
+	}
+	// One explosion is the spaceship being hit, the other is the bullet
+	found := false
+	for _, e := range blasts {
+		found = found || e.Y == b.Y-1 && e.X == b.X-1
+	}
+	if !found {
+		t.Errorf("no explosion where the bullet hit at %d,%d in %d explosions", b.Y, b.X, len(blasts))
+	}
+}
+
+func TestBossPlaysItsAnimation(t *testing.T) {
+	design, err := LoadBossDesign(os.DirFS(".."), "design/spaceship_boss_fight_minions.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := NewWorld(Options{Lines: 40, Cols: 120, Character: &Character{}, Level: Level{Time: 100, Waves: noSpawns}, Boss: &design})
+	boss := NewBoss(w, design)
+	frames := map[string]bool{}
+	for i := 0; i < 2*w.TickRate; i++ {
+		boss.Update(w)
+		frames[boss.Art[2]] = true
+	}
+	if len(frames) < 2 {
+		t.Errorf("the boss showed %d different frames, want it to be animated", len(frames))
+	}
+}
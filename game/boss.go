@@ -0,0 +1,343 @@
+package game
+
+import (
+	"fmt"
+	"io/fs"
+	"strings"
+)
+
+/* BossScore is how many points destroying the boss is worth */
+const BossScore = 5000
+
+/* BossCoreHealth is the health of the core of the boss */
+const BossCoreHealth = 60
+
+/* BossCannonHealth is the health of every cannon of the boss */
+const BossCannonHealth = 10
+
+/* BossFrameSeconds is how many seconds every frame of the animation of the boss is shown */
+const BossFrameSeconds = 0.2
+
+/* BossEntrySpeed is how many columns a second the boss flies in from the right edge */
+const BossEntrySpeed = DefaultTickRate
+
+/* The labels of the animations in the boss design file */
+const (
+	AnimationMinion       = "Minion"
+	AnimationMinionAttack = "Minion attack"
+	AnimationExplosion    = "Explosion"
+	AnimationThruster     = "Thruster"
+	AnimationBoss         = "Boss"
+)
+
+/* BossCannonMarker is what a cannon looks like in the ascii art of the boss */
+const BossCannonMarker = "(____)"
+
+/* A struct for what the boss and its minions look like and how things blow up and fly */
+type BossDesign struct {
+	Art          []string   // The ascii art of the boss
+	Minion       []string   // The ascii art of the minions the boss spawns
+	MinionFrames [][]string // The frames of the animation of the minions, Minion is used if there are none
+	MinionAttack *Animation // The animation the minions play when they shoot, the bullet explodes where it hits if it has an "explosion" frame
+	Animation    *Animation // The animation of the boss, Art is used if it is nil
+	Explosion    *Animation // The animation of every explosion, ExplosionAnimation if it is nil
+	Thruster     *Animation // The animation of the flame behind the spaceship, ThrusterAnimation if it is nil
+}
+
+/* DefaultBossDesign is the boss that is used when there is no design file */
+var DefaultBossDesign = BossDesign{
+	Art: []string{
+		`               _____.-----._____`,
+		`  ___----~~~~~~. ... ..... ... .~~~~~~----___`,
+		`===============================================`,
+		`  ~~~-----......._____________.......-----~~~`,
+		`  (____)          \    |   /           (____)`,
+		`     ||           _/   |   \_            ||`,
+		`      \\_______--~  //~~~\\   ~--_______//`,
+		`       ~~~~---__    \\___//   __---~~~~`,
+		`                 ~~-_______-~~`,
+	},
+	Minion: []string{
+		`  ______`,
+		`/   --   \`,
+		`/__________\`,
+	},
+}
+
+/* A function that reads the boss and the minions from design/spaceship_boss_fight_minions.txt, the boss is the first block and the animations are found by their "Animation: Name" labels */
+func LoadBossDesign(fsys fs.FS, filename string) (BossDesign, error) {
+	blocks, err := LoadDesignBlocks(fsys, filename)
+	if err != nil {
+		return BossDesign{}, err
+	}
+	design := BossDesign{}
+	if len(blocks) > 0 {
+		design.Art = TrimArt(blocks[0])
+	}
+	anims, err := LoadAnimations(fsys, filename, 0.15, true)
+	if err != nil {
+		return BossDesign{}, err
+	}
+	if minion, ok := FindAnimation(anims, AnimationMinion); ok {
+		for _, f := range minion.Frames {
+			design.MinionFrames = append(design.MinionFrames, f.Art)
+		}
+		design.Minion = design.MinionFrames[0]
+	}
+	if attack, ok := FindAnimation(anims, AnimationMinionAttack); ok {
+		design.MinionAttack = attack
+		design.MinionAttack.Loop = false
+	}
+	if explosion, ok := FindAnimation(anims, AnimationExplosion); ok {
+		design.Explosion = explosion
+		design.Explosion.Loop = false
+		design.Explosion.setSeconds(ExplosionSeconds)
+	}
+	if thruster, ok := FindAnimation(anims, AnimationThruster); ok {
+		design.Thruster = thruster
+		design.Thruster.setSeconds(ThrusterSeconds)
+	}
+	if boss, ok := FindAnimation(anims, AnimationBoss); ok {
+		design.Animation = boss
+		design.Animation.setSeconds(BossFrameSeconds)
+	}
+	if len(design.Art) == 0 || len(design.Minion) == 0 {
+		return BossDesign{}, fmt.Errorf("%s: no boss or no \"Animation: %s\"", filename, AnimationMinion)
+	}
+	return design, nil
+}
+
+/* A method that returns the animation of the explosions */
+func (d BossDesign) explosion() *Animation {
+	if d.Explosion == nil {
+		return ExplosionAnimation
+	}
+	return d.Explosion
+}
+
+/* A method that returns the animation of the flame behind the spaceship */
+func (d BossDesign) thruster() *Animation {
+	if d.Thruster == nil {
+		return ThrusterAnimation
+	}
+	return d.Thruster
+}
+
+/* A struct for a part of the boss that can be shot on its own */
+type BossPart struct {
+	Box       Hitbox // Where the part is inside of the ascii art of the boss
+	Health    int
+	MaxHealth int
+	Cannon    bool // If the part shoots, the core doesn't
+}
+
+/* A struct for the boss, it is made of a core and cannons that all have their own health */
+type Boss struct {
+	Body
+	Parts      []*BossPart
+	Phase      int // The phase of the fight starting at 1, it goes up as the boss loses health
+	minion     EnemyType
+	shootTimer int
+	spawnTimer int
+	dirY       int
+	arrived    bool
+	moved      mover     // What is left of flying in or moving up and down a part of a cell
+	anim       *Animator // The animation of the boss, nil if the design has none
+}
+
+/* A function that makes a new boss off the right edge of the world */
+func NewBoss(w *World, design BossDesign) *Boss {
+	box := HitboxFor(0, 0, design.Art)
+	b := &Boss{
+		Body:  Body{Y: (w.Lines - box.H) / 2, X: w.Cols, Art: design.Art, Tint: "magenta"},
+		Phase: 1,
+		minion: EnemyType{
+			Name:          "minion",
+			AsciiArt:      design.Minion,
+			Color:         "red",
+			Health:        1,
+			Speed:         20,
+			FireRate:      0.4,
+			BulletPattern: "single",
+			BulletSymbol:  "-",
+			ScoreValue:    50,
+			Movement:      "sine",
+			Frames:        design.MinionFrames,
+			FrameTime:     0.15,
+			Attack:        design.MinionAttack,
+		},
+		dirY: 1,
+	}
+	if design.Animation != nil && len(design.Animation.Frames) > 0 {
+		b.anim = NewAnimator(design.Animation)
+		b.Art = b.anim.Art()
+	}
+	b.Parts = append(b.Parts, &BossPart{Box: box, Health: BossCoreHealth, MaxHealth: BossCoreHealth})
+	for y, line := range design.Art {
+		for off := 0; ; {
+			i := strings.Index(line[off:], BossCannonMarker)
+			if i < 0 {
+				break
+			}
+			b.Parts = append(b.Parts, &BossPart{
+				Box:       Hitbox{Y: y, X: len([]rune(line[:off+i])), H: 2, W: len(BossCannonMarker)},
+				Health:    BossCannonHealth,
+				MaxHealth: BossCannonHealth,
+				Cannon:    true,
+			})
+			off += i + len(BossCannonMarker)
+		}
+	}
+	return b
+}
+
+/* A method that returns the health left and the most health of the boss */
+func (b *Boss) Health() (int, int) {
+	health, max := 0, 0
+	for _, p := range b.Parts {
+		health += p.Health
+		max += p.MaxHealth
+	}
+	return health, max
+}
+
+/* A method that returns the core of the boss */
+func (b *Boss) core() *BossPart {
+	return b.Parts[0]
+}
+
+/* A method that moves the boss, changes phases and attacks */
+func (b *Boss) Update(w *World) {
+	if b.anim != nil {
+		b.anim.Update(w.TickRate)
+		b.Art = b.anim.Art()
+	}
+	box := b.Hitbox()
+	if !b.arrived {
+		// Fly in from the right edge until the whole boss is on the screen
+		b.X -= b.moved.step(BossEntrySpeed, w.TickRate)
+		b.arrived = b.X+box.W <= w.Cols-4
+		return
+	}
+	// The boss moves up and down faster in every phase
+	if b.moved.step(DefaultTickRate/(4-b.Phase), w.TickRate) > 0 {
+		b.Y += b.dirY
+		if b.Y <= 2 || b.Y+box.H >= w.Lines-1 {
+			b.dirY = -b.dirY
+		}
+	}
+	b.Y = clamp(b.Y, 2, w.Lines-box.H-1)
+
+	health, max := b.Health()
+	phase := 1
+	if health*3 <= max*2 {
+		phase = 2
+	}
+	if health*3 <= max {
+		phase = 3
+	}
+	if phase != b.Phase {
+		b.Phase = phase
+		b.spawnMinions(w, phase)
+	}
+
+	b.shootTimer--
+	if b.shootTimer <= 0 {
+		b.shootTimer = w.TickRate * (5 - b.Phase) / 3
+		b.attack(w)
+	}
+	if b.Phase > 1 {
+		b.spawnTimer--
+		if b.spawnTimer <= 0 {
+			b.spawnTimer = w.TickRate * (8 - 2*b.Phase)
+			b.spawnMinions(w, b.Phase)
+		}
+	}
+}
+
+/* A method that shoots from every cannon that is left, the core shoots too after the first phase or when no cannons are left */
+func (b *Boss) attack(w *World) {
+	fire := func(y, x, dirY int, symbol string) {
+		bullet := NewBullet(y, x, -1)
+		bullet.DirY = dirY
+		bullet.Art = []string{symbol}
+		w.Spawn(bullet)
+	}
+	cannons := 0
+	for _, p := range b.Parts[1:] {
+		if p.Health <= 0 {
+			continue
+		}
+		cannons++
+		y, x := b.Y+p.Box.Y, b.X+p.Box.X-1
+		if b.Phase == 1 {
+			fire(y, x, 0, "-")
+			fire(y+1, x, 0, "-")
+		} else {
+			fire(y, x, -1, "-")
+			fire(y, x, 0, "-")
+			fire(y, x, 1, "-")
+		}
+	}
+	if b.Phase > 1 || cannons == 0 {
+		box := b.Hitbox()
+		fire(b.Y+box.H/2, b.X-1, 0, "*")
+	}
+}
+
+/* A method that spawns count minions in a column in front of the boss */
+func (b *Boss) spawnMinions(w *World, count int) {
+	box := b.Hitbox()
+	for i := 0; i < count; i++ {
+		y := b.Y + i*box.H/count
+		w.Spawn(NewEnemyShip(y, b.X-HitboxFor(0, 0, b.minion.AsciiArt).W-2, &b.minion, w.TickRate))
+	}
+}
+
+/* A method that returns the collision layer of the boss */
+func (b *Boss) Layer() Layer { return LayerEnemy }
+
+/* A method that returns the layers the boss collides with */
+func (b *Boss) Mask() Layer { return LayerPlayer | LayerPlayerBullet }
+
+/* A method that is called when the boss is shot, the cannon that was hit loses health or else the core does */
+func (b *Boss) OnHit(w *World, other Collider) {
+	if other.Layer() != LayerPlayerBullet || !b.arrived {
+		return
+	}
+	hit := other.Hitbox()
+	part := b.core()
+	for _, p := range b.Parts[1:] {
+		box := p.Box
+		box.Y += b.Y
+		box.X += b.X
+		if p.Health > 0 && box.Overlaps(hit) {
+			part = p
+			break
+		}
+	}
+	part.Health -= DamageOf(other)
+	if part.Health < 0 {
+		part.Health = 0
+	}
+	if part.Cannon && part.Health <= 0 {
+		w.blowUp(b.Y+part.Box.Y, b.X+part.Box.X)
+	}
+	if health, _ := b.Health(); health <= 0 || b.core().Health <= 0 {
+		for _, p := range b.Parts {
+			p.Health = 0
+			w.blowUp(b.Y+p.Box.Y+p.Box.H/2, b.X+p.Box.X+p.Box.W/2)
+		}
+		w.AddScore(BossScore)
+	}
+}
+
+/* A method that checks if the boss has been destroyed */
+func (b *Boss) Expired(w *World) bool {
+	return b.Defeated()
+}
+
+/* A method that checks if the core of the boss has been destroyed */
+func (b *Boss) Defeated() bool {
+	return b.core().Health <= 0
+}
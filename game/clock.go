@@ -0,0 +1,56 @@
+package game
+
+import "time"
+
+/* MaxCatchUpSteps is the most steps a Clock hands out at once so a long stall doesn't freeze the game catching up */
+const MaxCatchUpSteps = 5
+
+/* A struct for a fixed-timestep clock that accumulates real time and says how many steps of the simulation are due */
+type Clock struct {
+	Step time.Duration // How long one step of the simulation is
+	acc  time.Duration
+	last time.Time
+}
+
+/* A function that makes a new clock for a tick rate starting at now */
+func NewClock(tickRate int, now time.Time) *Clock {
+	return &Clock{Step: time.Second / time.Duration(tickRate), last: now}
+}
+
+/* A method that adds the time since the last call and returns how many steps are due */
+func (c *Clock) Advance(now time.Time) int {
+	c.acc += now.Sub(c.last)
+	c.last = now
+	steps := int(c.acc / c.Step)
+	if steps > MaxCatchUpSteps {
+		steps = MaxCatchUpSteps
+		c.acc = 0
+	} else {
+		c.acc -= time.Duration(steps) * c.Step
+	}
+	return steps
+}
+
+/* A method that returns how long to wait until the next step is due */
+func (c *Clock) Wait() time.Duration {
+	return c.Step - c.acc
+}
+
+/* A method that forgets the time that has gone by so the next Advance starts from now */
+func (c *Clock) Reset(now time.Time) {
+	c.acc = 0
+	c.last = now
+}
+
+/* A struct that turns a speed in cells a second into whole cells every tick, the part of a cell left over is kept for the next tick */
+type mover struct {
+	moved int // Cells moved times the tick rate that haven't been moved yet
+}
+
+/* A method that returns how many cells to move in this tick at a speed in cells a second */
+func (m *mover) step(speed, tickRate int) int {
+	m.moved += speed
+	cells := m.moved / tickRate
+	m.moved -= cells * tickRate
+	return cells
+}
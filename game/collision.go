@@ -0,0 +1,143 @@
+package game
+
+import "strings"
+
+/* CollisionCellSize is the size of one cell of the spatial hash used to find what can collide */
+const CollisionCellSize = 8
+
+/* A bit mask of collision layers */
+type Layer uint8
+
+/* The collision layers, an object is on one layer and has a mask of the layers it can collide with */
+const (
+	LayerPlayer Layer = 1 << iota
+	LayerEnemy
+	LayerPlayerBullet
+	LayerEnemyBullet
+	LayerPickup
+)
+
+/* A struct for a box that an object can be hit in */
+type Hitbox struct {
+	Y, X int // The top left corner
+	H, W int // The height and the width
+}
+
+/* A method that checks if two hitboxes overlap */
+func (h Hitbox) Overlaps(o Hitbox) bool {
+	return h.X < o.X+o.W && o.X < h.X+h.W && h.Y < o.Y+o.H && o.Y < h.Y+h.H
+}
+
+/* A function that returns the hitbox of ascii art at y, x using the actual size of the art */
+func HitboxFor(y, x int, art []string) Hitbox {
+	w := 0
+	for _, line := range art {
+		if n := len([]rune(strings.TrimRight(line, " "))); n > w {
+			w = n
+		}
+	}
+	return Hitbox{Y: y, X: x, H: len(art), W: w}
+}
+
+/* An interface for an object that can collide with other objects */
+type Collider interface {
+	Object
+	Hitbox() Hitbox
+	Layer() Layer                   // The layer the object is on
+	Mask() Layer                    // The layers the object collides with
+	OnHit(w *World, other Collider) // Called when the object is hit by something in its mask
+}
+
+/* An interface for a collider that can refuse to hit something, like a piercing bullet that already went through it */
+type hitFilter interface {
+	CanHit(other Collider) bool
+}
+
+/* A function that checks if a is allowed to hit b */
+func canHit(a, b Collider) bool {
+	f, ok := a.(hitFilter)
+	return !ok || f.CanHit(b)
+}
+
+/* A struct for a uniform grid that remembers which hitboxes are in which cells */
+type SpatialHash struct {
+	cellSize int
+	cells    map[[2]int][]int
+}
+
+/* A function that makes a new spatial hash with square cells of cellSize */
+func NewSpatialHash(cellSize int) *SpatialHash {
+	return &SpatialHash{cellSize: cellSize, cells: make(map[[2]int][]int)}
+}
+
+/* A method that adds the item id with a hitbox to every cell the hitbox touches */
+func (s *SpatialHash) Insert(id int, h Hitbox) {
+	s.each(h, func(key [2]int) {
+		s.cells[key] = append(s.cells[key], id)
+	})
+}
+
+/* A method that calls fn once for every item that shares a cell with the hitbox */
+func (s *SpatialHash) Query(h Hitbox, fn func(id int)) {
+	seen := make(map[int]bool)
+	s.each(h, func(key [2]int) {
+		for _, id := range s.cells[key] {
+			if !seen[id] {
+				seen[id] = true
+				fn(id)
+			}
+		}
+	})
+}
+
+/* A method that calls fn with every cell the hitbox touches */
+func (s *SpatialHash) each(h Hitbox, fn func(key [2]int)) {
+	y0, x0 := floorDiv(h.Y, s.cellSize), floorDiv(h.X, s.cellSize)
+	y1, x1 := floorDiv(h.Y+h.H-1, s.cellSize), floorDiv(h.X+h.W-1, s.cellSize)
+	for cy := y0; cy <= y1; cy++ {
+		for cx := x0; cx <= x1; cx++ {
+			fn([2]int{cy, cx})
+		}
+	}
+}
+
+/* A function that divides and rounds down even for negative numbers */
+func floorDiv(a, b int) int {
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+	return q
+}
+
+/* A method that finds every pair of colliders that overlap and lets them hit each other */
+func (w *World) collide() {
+	var colliders []Collider
+	for _, ob := range w.Objects {
+		if c, ok := ob.(Collider); ok && !ob.Expired(w) {
+			colliders = append(colliders, c)
+		}
+	}
+	hash := NewSpatialHash(CollisionCellSize)
+	for i, c := range colliders {
+		hash.Insert(i, c.Hitbox())
+	}
+	for i, a := range colliders {
+		hash.Query(a.Hitbox(), func(j int) {
+			b := colliders[j]
+			if j <= i || a.Expired(w) || b.Expired(w) {
+				return
+			}
+			hitsB, hitsA := a.Mask()&b.Layer() != 0, b.Mask()&a.Layer() != 0
+			if !hitsA && !hitsB || !canHit(a, b) || !canHit(b, a) || !a.Hitbox().Overlaps(b.Hitbox()) {
+				return
+			}
+			if hitsB {
+				a.OnHit(w, b)
+			}
+			if hitsA {
+				b.OnHit(w, a)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package game
+
+import (
+	"encoding/json"
+	"io"
+	"io/fs"
+)
+
+/* A json structure for a Character */
+type Character struct {
+	Name       string     `json:"name"`                /* This is the name of the character */
+	AsciiArt   []string   `json:"ascii_art"`           /* This is the ascii art of the character */
+	Attributes Attributes `json:"attributes"`          /* These is the attributes of the character */
+	Weapons    []Weapon   `json:"weapons,omitempty"`   /* The weapons the character cycles through, a blaster if there are none */
+	Secondary  *Weapon    `json:"secondary,omitempty"` /* The weapon shot with the secondary key */
+}
+
+/* A json structure for the attributes of a character */
+type Attributes struct {
+	MaxHealth   int     `json:"max_health"`             /* The amount of damage the character can take */
+	Shield      int     `json:"shield,omitempty"`       /* The hits the shield of the character takes before its health does */
+	ShieldRegen float64 `json:"shield_regen,omitempty"` /* The seconds it takes the shield to get back one hit, 0 if it doesn't regenerate */
+	Firepower   int     `json:"firepower"`              /* How many times the damage of its weapons the character deals */
+	FireRate    float64 `json:"fire_rate"`              /* How many times faster than its weapons the character shoots */
+	Speed       int     `json:"speed"`                  /* The speed of the character */
+	Hitbox      *Size   `json:"hitbox,omitempty"`       /* The size of the hitbox of the character, the size of its ascii art if it is not set */
+	Color       string  `json:"color"`                  /* The colour of the character */
+	Damage      int     `json:"damage,omitempty"`       /* The health of the character in old characters.json files, use max_health instead */
+}
+
+/* A json structure for the size of a hitbox */
+type Size struct {
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+/* A method that fills in the attributes that are missing, old files that only have damage get it as their max health */
+func (a Attributes) WithDefaults() Attributes {
+	if a.MaxHealth <= 0 {
+		a.MaxHealth = a.Damage
+	}
+	if a.MaxHealth <= 0 {
+		a.MaxHealth = 5
+	}
+	a.Damage = 0
+	if a.Shield < 0 {
+		a.Shield = 0
+	}
+	if a.ShieldRegen < 0 {
+		a.ShieldRegen = 0
+	}
+	if a.Firepower <= 0 {
+		a.Firepower = 1
+	}
+	if a.FireRate <= 0 {
+		a.FireRate = 1
+	}
+	if a.Speed <= 0 {
+		a.Speed = 1
+	}
+	if a.Hitbox != nil && (a.Hitbox.Width <= 0 || a.Hitbox.Height <= 0) {
+		a.Hitbox = nil
+	}
+	return a
+}
+
+/* A json structure for the all of the characters */
+type Characters struct {
+	Characters []Character `json:"characters"`
+}
+
+/* A json structure for a level */
+type Level struct {
+	Number     int      `json:"number"`
+	Enemies    int      `json:"enemies"`
+	Time       int      `json:"time"`
+	Score      int      `json:"score"`
+	EnemyTypes []string `json:"enemy_types,omitempty"` /* The names of the enemy types in enemies.json that spawn in this level */
+	Waves      []Wave   `json:"waves,omitempty"`       /* The timeline of waves, enemies spawn randomly if there are none */
+	Boss       bool     `json:"boss,omitempty"`        /* If the level ends with a boss fight instead of being won straight away */
+	Drops      []Drop   `json:"drops,omitempty"`       /* What destroyed enemies drop when their type has no drops of its own */
+}
+
+/* A json structure for all of the levels */
+type Levels struct {
+	Levels []Level `json:"levels"`
+}
+
+/* A function that loads all of the characters from a json file in fsys */
+func LoadCharacters(fsys fs.FS, filename string) (Characters, error) {
+	var characters Characters
+	if err := loadJSON(fsys, filename, &characters); err != nil {
+		return characters, err
+	}
+	for i := range characters.Characters {
+		characters.Characters[i].Attributes = characters.Characters[i].Attributes.WithDefaults()
+	}
+	return characters, nil
+}
+
+/* A function that loads all of the levels from a json file in fsys */
+func LoadLevels(fsys fs.FS, filename string) (Levels, error) {
+	var levels Levels
+	err := loadJSON(fsys, filename, &levels)
+	return levels, err
+}
+
+/* A function that reads a json file in fsys and unmarshals it into v */
+func loadJSON(fsys fs.FS, filename string, v interface{}) error {
+	file, err := fsys.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	// Read the JSON data from the file
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return err
+	}
+
+	// Unmarshal the JSON data into v
+	return json.Unmarshal(data, v)
+}
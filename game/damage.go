@@ -0,0 +1,64 @@
+package game
+
+/* InvulnerableSeconds is how long the spaceship can't be hurt again after being hit */
+const InvulnerableSeconds = 1.5
+
+/* HitFlashSeconds is how long the life bar flashes after the spaceship is hit */
+const HitFlashSeconds = 0.4
+
+/* BlinkTicks is how many ticks the spaceship is shown and then hidden for while it is invulnerable */
+const BlinkTicks = 2
+
+/* A method that hurts the spaceship once, the shield takes the hit before the life does and the spaceship can't be hurt again until its invulnerability frames are over */
+func (s *Ship) takeHit(w *World) {
+	if s.Invulnerable() {
+		return
+	}
+	w.Spawn(NewExplosion(s.Y, s.X, w.bossDesign.explosion()))
+	w.emit(SoundHit)
+	if s.Shield > 0 {
+		s.Shield--
+	} else {
+		s.Life--
+	}
+	s.invulnerable = int(InvulnerableSeconds * float64(w.TickRate))
+	s.flash = int(HitFlashSeconds * float64(w.TickRate))
+	s.regen = 0 // The shield only regenerates while the spaceship isn't being hit
+}
+
+/* A method that counts down the invulnerability frames and regenerates the shield */
+func (s *Ship) updateDamage(w *World) {
+	if s.invulnerable > 0 {
+		s.invulnerable--
+	}
+	if s.flash > 0 {
+		s.flash--
+	}
+	if s.ShieldRegen <= 0 || s.Shield >= s.MaxShield {
+		s.regen = 0
+		return
+	}
+	s.regen++
+	if s.regen >= int(s.ShieldRegen*float64(w.TickRate)) {
+		s.Shield++
+		s.regen = 0
+	}
+}
+
+/* A method that checks if the spaceship can't be hurt right now */
+func (s *Ship) Invulnerable() bool {
+	return s.invulnerable > 0
+}
+
+/* A method that checks if the life bar should flash because the spaceship was just hit */
+func (s *Ship) Flashing() bool {
+	return s.flash > 0
+}
+
+/* A method that returns the ascii art of the spaceship, nothing every other few ticks while it is invulnerable so that it blinks */
+func (s *Ship) Sprite() []string {
+	if s.invulnerable > 0 && (s.invulnerable/BlinkTicks)%2 == 1 {
+		return nil
+	}
+	return s.Art
+}
@@ -0,0 +1,52 @@
+package game
+
+import (
+	"io/fs"
+	"strings"
+)
+
+/* A function that reads a design file in fsys and returns its blocks of ascii art, blocks are separated by empty lines */
+func LoadDesignBlocks(fsys fs.FS, filename string) ([][]string, error) {
+	content, err := fs.ReadFile(fsys, filename)
+	if err != nil {
+		return nil, err
+	}
+	return DesignBlocks(string(content)), nil
+}
+
+/* A function that splits the contents of a design file into blocks of ascii art separated by empty lines */
+func DesignBlocks(content string) [][]string {
+	var blocks [][]string
+	var block []string
+	for _, line := range strings.Split(strings.ReplaceAll(content, "\r", ""), "\n") {
+		line = strings.TrimRight(line, " \t")
+		if line == "" {
+			if len(block) > 0 {
+				blocks = append(blocks, block)
+				block = nil
+			}
+			continue
+		}
+		block = append(block, line)
+	}
+	if len(block) > 0 {
+		blocks = append(blocks, block)
+	}
+	return blocks
+}
+
+/* A function that removes the indentation every line of ascii art has in common */
+func TrimArt(art []string) []string {
+	indent := -1
+	for _, line := range art {
+		n := len(line) - len(strings.TrimLeft(line, " "))
+		if indent == -1 || n < indent {
+			indent = n
+		}
+	}
+	out := make([]string, len(art))
+	for i, line := range art {
+		out[i] = line[indent:]
+	}
+	return out
+}
@@ -0,0 +1,232 @@
+package game
+
+import (
+	"io/fs"
+	"math"
+)
+
+/* ZigzagSpeed is how many lines a second an enemy with the zigzag movement moves up or down */
+const ZigzagSpeed = DefaultTickRate / 2
+
+/* A json structure for a type of enemy */
+type EnemyType struct {
+	Name          string     `json:"name"`                 /* The name levels use to spawn this enemy */
+	AsciiArt      []string   `json:"ascii_art"`            /* The ascii art of the enemy */
+	Color         string     `json:"color"`                /* The colour of the enemy */
+	Health        int        `json:"health"`               /* How many hits the enemy can take */
+	Speed         int        `json:"speed"`                /* How many columns the enemy moves in a second */
+	FireRate      float64    `json:"fire_rate"`            /* How many times the enemy shoots in a second, 0 never shoots */
+	BulletPattern string     `json:"bullet_pattern"`       /* How the enemy shoots: single, double or spread */
+	BulletSymbol  string     `json:"bullet_symbol"`        /* What the bullets of the enemy look like */
+	ScoreValue    int        `json:"score_value"`          /* How many points destroying the enemy is worth */
+	Movement      string     `json:"movement"`             /* How the enemy moves: straight, sine or zigzag */
+	Frames        [][]string `json:"frames,omitempty"`     /* The frames of the animation of the enemy, ascii_art is used if there are none */
+	FrameTime     float64    `json:"frame_time,omitempty"` /* How many seconds every frame is shown */
+	Drops         []Drop     `json:"drops,omitempty"`      /* What the enemy can drop when it is destroyed, the drops of the level are used if there are none */
+	Attack        *Animation `json:"-"`                    /* The animation played when the enemy shoots, the enemy shoots once when it starts */
+}
+
+/* A json structure for all of the enemy types */
+type EnemyTypes struct {
+	Enemies []EnemyType `json:"enemies"`
+}
+
+/* DefaultEnemyType is the enemy that is used when there are no enemy types */
+var DefaultEnemyType = EnemyType{
+	Name:          "grunt",
+	AsciiArt:      EnemyArt,
+	Health:        1,
+	Speed:         DefaultTickRate,
+	FireRate:      0.5,
+	BulletPattern: "double",
+	BulletSymbol:  "-",
+	ScoreValue:    EnemyScore,
+	Movement:      "straight",
+}
+
+/* A function that loads all of the enemy types from a json file in fsys */
+func LoadEnemies(fsys fs.FS, filename string) (EnemyTypes, error) {
+	var enemies EnemyTypes
+	err := loadJSON(fsys, filename, &enemies)
+	return enemies, err
+}
+
+/* A method that returns the enemy type with a name */
+func (e EnemyTypes) Find(name string) (*EnemyType, bool) {
+	for i := range e.Enemies {
+		if e.Enemies[i].Name == name {
+			return &e.Enemies[i], true
+		}
+	}
+	return nil, false
+}
+
+/* A method that returns the enemy types a level spawns, every type if the level doesn't say */
+func (e EnemyTypes) ForLevel(level Level) []*EnemyType {
+	var types []*EnemyType
+	for _, name := range level.EnemyTypes {
+		if t, ok := e.Find(name); ok {
+			types = append(types, t)
+		}
+	}
+	if len(level.EnemyTypes) == 0 {
+		for i := range e.Enemies {
+			types = append(types, &e.Enemies[i])
+		}
+	}
+	if len(types) == 0 {
+		types = append(types, &DefaultEnemyType)
+	}
+	return types
+}
+
+/* A struct for the ememies spaceships */
+type EnemyShip struct {
+	Body
+	Type       *EnemyType
+	Health     int
+	alive      bool
+	shootTimer int       // Ticks left until the enemy ship shoots
+	shootTicks int       // Ticks between two shots
+	bulletDirX int       // X-direction for enemy ship bullets (-1 for left, 1 for right)
+	moved      mover     // What is left of moving a part of a column
+	climbed    mover     // What is left of moving a part of a line in the zigzag movement
+	baseY      int       // The line the enemy ship spawned on for the movement patterns
+	dirY       int       // The direction the enemy ship is going in the zigzag movement
+	anim       *Animator // The animation being played, the attack while the enemy shoots
+	idle       *Animator // The animation played when the enemy isn't shooting
+}
+
+/* A function that makes a new enemy ship of a type for a world that is stepped tickRate times a second */
+func NewEnemyShip(y, x int, t *EnemyType, tickRate int) *EnemyShip {
+	shootTicks := 0
+	if t.FireRate > 0 {
+		shootTicks = int(math.Max(1, float64(tickRate)/t.FireRate))
+	}
+	health := t.Health
+	if health <= 0 {
+		health = 1
+	}
+	e := &EnemyShip{
+		Body:       Body{Y: y, X: x, Art: t.AsciiArt, Tint: t.Color},
+		Type:       t,
+		Health:     health,
+		alive:      true,
+		shootTimer: shootTicks,
+		shootTicks: shootTicks,
+		bulletDirX: -1,
+		baseY:      y,
+		dirY:       1,
+	}
+	if len(t.Frames) > 0 {
+		frameTime := t.FrameTime
+		if frameTime <= 0 {
+			frameTime = 0.25
+		}
+		e.idle = NewAnimator(NewAnimation(t.Frames, frameTime, true))
+		e.anim = e.idle
+		e.Art = e.anim.Art()
+	}
+	return e
+}
+
+/* A function that updates a enemy ship */
+func (e *EnemyShip) Update(w *World) {
+	if e.anim != nil {
+		e.anim.Update(w.TickRate)
+		if e.anim.Done {
+			// The attack is over, go back to the idle animation or the ascii art
+			e.anim = e.idle
+		}
+	}
+	e.move(w)
+	if e.shootTicks > 0 {
+		e.shootTimer--
+		if e.shootTimer <= 0 {
+			e.shootTimer = e.shootTicks
+			e.attack(w)
+		}
+	}
+	e.Art = e.Type.AsciiArt
+	if e.anim != nil {
+		e.Art = e.anim.Art()
+	}
+}
+
+/* A method that shoots and plays the attack animation of the type of the enemy ship if it has one, the bullets blow up where they hit if the attack has an "explosion" frame */
+func (e *EnemyShip) attack(w *World) {
+	attack := e.Type.Attack
+	if attack == nil || len(attack.Frames) == 0 {
+		e.shoot(w, false)
+		return
+	}
+	e.anim = NewAnimator(attack)
+	e.shoot(w, attack.HasEvent(EventExplosion))
+}
+
+/* A method that moves the enemy ship using the speed and the movement pattern of its type */
+func (e *EnemyShip) move(w *World) {
+	e.X -= e.moved.step(e.Type.Speed, w.TickRate)
+	switch e.Type.Movement {
+	case "sine":
+		e.Y = e.baseY + int(math.Round(3*math.Sin(float64(w.Ticks)/float64(w.TickRate))))
+	case "zigzag":
+		e.Y += e.dirY * e.climbed.step(ZigzagSpeed, w.TickRate)
+		if e.Y <= 2 || e.Y+len(e.Art) >= w.Lines-1 {
+			e.dirY = -e.dirY
+		}
+	}
+	e.Y = clamp(e.Y, 1, w.Lines-len(e.Art))
+}
+
+/* A method that creates the bullets of the enemy ship using the bullet pattern of its type, blast bullets show an explosion where they hit */
+func (e *EnemyShip) shoot(w *World, blast bool) {
+	// Create bullets for enemy ships when they shoot, but in the opposite direction
+	fire := func(dy, dirY int) {
+		b := NewBullet(e.Y+dy, e.X-1, e.bulletDirX)
+		b.DirY = dirY
+		b.Blast = blast
+		if e.Type.BulletSymbol != "" {
+			b.Art = []string{e.Type.BulletSymbol}
+		}
+		w.Spawn(b)
+	}
+	mid := len(e.Art) / 2
+	switch e.Type.BulletPattern {
+	case "single":
+		fire(mid, 0)
+	case "spread":
+		fire(mid, -1)
+		fire(mid, 0)
+		fire(mid, 1)
+	default:
+		fire(1, 0)
+		fire(3, 0)
+	}
+}
+
+/* A method that returns the collision layer of the enemy ship */
+func (e *EnemyShip) Layer() Layer { return LayerEnemy }
+
+/* A method that returns the layers the enemy ship collides with */
+func (e *EnemyShip) Mask() Layer { return LayerPlayer | LayerPlayerBullet }
+
+/* A method that is called when the enemy ship is shot or rams the spaceship */
+func (e *EnemyShip) OnHit(w *World, other Collider) {
+	if other.Layer() == LayerPlayerBullet {
+		e.Health -= DamageOf(other)
+		if e.Health > 0 {
+			return
+		}
+		w.AddScore(e.Type.ScoreValue)
+		w.Kills++
+		w.dropPickup(e.Type.Drops, e.Y+len(e.Art)/2, e.X)
+	}
+	w.blowUp(e.Y, e.X)
+	e.alive = false
+}
+
+/* A function that checks if the ememy ship has expired/died/goneOffTheScreen */
+func (e *EnemyShip) Expired(w *World) bool {
+	return e.X+HitboxFor(e.Y, e.X, e.Art).W <= 0 || !e.alive
+}
@@ -0,0 +1,359 @@
+package game
+
+/* ShipArt is the default ascii art for the spaceship */
+var ShipArt = []string{
+	` ,     `,
+	` |\-   `,
+	`>|^===0`,
+	` |/-    `,
+	` '      `,
+}
+
+/* EnemyArt is the ascii art for the enemies */
+var EnemyArt = []string{
+	`  ^^^  `,
+	`{(-+-)}`,
+	`{(-+-)}`,
+	`{(-+-)}`,
+	`  ***  `,
+}
+
+/* ExplosionArt is the ascii art for an explosion */
+var ExplosionArt = []string{
+	`.** *. *`,
+	`*. *.*. `,
+	`.** **.*`,
+	`*.*.***.`,
+}
+
+/* ExplosionSeconds is how many seconds every frame of an explosion is shown */
+const ExplosionSeconds = 0.08
+
+/* ThrusterSeconds is how many seconds every frame of the flame behind the spaceship is shown */
+const ThrusterSeconds = 0.1
+
+/* ExplosionAnimation is the animation of an explosion that is used when the design file has none, it grows into ExplosionArt and fades away */
+var ExplosionAnimation = NewAnimation([][]string{
+	{
+		`        `,
+		`  .**.  `,
+		`  *..*  `,
+		`        `,
+	},
+	ExplosionArt,
+	{
+		`. *  . *`,
+		` .  *.  `,
+		`*  .  . `,
+		` . *  .*`,
+	},
+	{
+		`.      .`,
+		`   .    `,
+		`  .   . `,
+		`.    .  `,
+	},
+}, ExplosionSeconds, false)
+
+/* ThrusterAnimation is the animation of the flame behind the spaceship that is used when the design file has none */
+var ThrusterAnimation = NewAnimation([][]string{{`-`}, {`=`}, {`~`}, {`=`}}, ThrusterSeconds, true)
+
+/* BulletArt is the ascii art for a bullet */
+var BulletArt = []string{`-`}
+
+/* BulletClimbSpeed is how many lines a second a bullet going up or down moves for every line of its DirY */
+const BulletClimbSpeed = DefaultTickRate / 4
+
+/* HomingSpeed is how many lines a second a homing bullet steers towards an enemy */
+const HomingSpeed = DefaultTickRate / 2
+
+/* An interface for any object in the world such as the spaceship, the enemies, the explosions, and the bullets */
+type Object interface {
+	YX() (int, int)
+	Sprite() []string
+	Color() string
+	Update(*World)
+	Expired(*World) bool
+}
+
+/* A struct holding the position, the ascii art and the colour of an object */
+type Body struct {
+	Y, X int
+	Art  []string
+	Tint string
+}
+
+/* A method that returns the position of a body */
+func (b *Body) YX() (int, int) {
+	return b.Y, b.X
+}
+
+/* A method that returns the ascii art of a body */
+func (b *Body) Sprite() []string {
+	return b.Art
+}
+
+/* A method that returns the colour of a body */
+func (b *Body) Color() string {
+	return b.Tint
+}
+
+/* A method that returns the hitbox of a body from the size of its ascii art */
+func (b *Body) Hitbox() Hitbox {
+	return HitboxFor(b.Y, b.X, b.Art)
+}
+
+/* A struct for the spaceship */
+type Ship struct {
+	Body
+	Life      int
+	MaxLife   int
+	Shield    int // The hits the shield takes before the life does
+	MaxShield int
+	Score     int
+	Speed     int
+	Firepower int                // How many times the damage of the weapons the spaceship deals
+	FireRate  float64            // How many times faster than the weapons the spaceship shoots
+	size      *Size              // The size of the hitbox if the character has one
+	Effects   map[PickupKind]int // The ticks left of every timed pickup that is active
+	Weapons   []*WeaponState     // The weapons that can be cycled through
+	Active    int                // The index of the weapon being used
+	Secondary *WeaponState       // The weapon shot with the secondary key, nil if there is none
+
+	ShieldRegen  float64 // The seconds it takes the shield to get back one hit, 0 if it doesn't regenerate
+	moveX        int     // The direction along the line of the last keys pressed, -1, 0 or 1
+	moveY        int     // The direction up or down of the last keys pressed, -1, 0 or 1
+	moveCells    int     // Cells the spaceship still has to move for the last keys pressed
+	moved        mover   // What is left of moving a part of a cell
+	invulnerable int     // Ticks left until the spaceship can be hurt again
+	flash        int     // Ticks left of the flash of the life bar
+	regen        int     // Ticks since the shield last regenerated or the spaceship was hit
+}
+
+/* A function that makes the new spaceship */
+func NewShip(y, x int, character *Character) *Ship {
+	art := character.AsciiArt
+	if len(art) == 0 {
+		art = ShipArt
+	}
+	attributes := character.Attributes.WithDefaults()
+	s := &Ship{
+		Body:      Body{Y: y, X: x, Art: art, Tint: attributes.Color},
+		Life:      attributes.MaxHealth,
+		MaxLife:   attributes.MaxHealth,
+		Shield:    attributes.Shield,
+		MaxShield: attributes.Shield,
+		Speed:     attributes.Speed,
+		Firepower: attributes.Firepower,
+		FireRate:  attributes.FireRate,
+		size:      attributes.Hitbox,
+
+		ShieldRegen: attributes.ShieldRegen,
+	}
+	for _, weapon := range character.Weapons {
+		s.Weapons = append(s.Weapons, NewWeaponState(weapon))
+	}
+	if len(s.Weapons) == 0 {
+		s.Weapons = append(s.Weapons, NewWeaponState(DefaultWeapon))
+	}
+	if character.Secondary != nil {
+		s.Secondary = NewWeaponState(*character.Secondary)
+	}
+	return s
+}
+
+/* A function that counts down the timed pickups, the weapon cooldowns and the invulnerability frames of the spaceship */
+func (s *Ship) Update(w *World) {
+	s.updateEffects()
+	s.coolWeapons()
+	s.updateDamage(w)
+}
+
+/* A function that checks if the spaceship has died */
+func (s *Ship) Expired(w *World) bool {
+	return s.Life <= 0
+}
+
+/* A method that returns the hitbox of the spaceship, centred on its ascii art if the character has a hitbox size */
+func (s *Ship) Hitbox() Hitbox {
+	box := s.Body.Hitbox()
+	if s.size == nil {
+		return box
+	}
+	return Hitbox{
+		Y: box.Y + (box.H-s.size.Height)/2,
+		X: box.X + (box.W-s.size.Width)/2,
+		H: s.size.Height,
+		W: s.size.Width,
+	}
+}
+
+/* A method that returns the collision layer of the spaceship */
+func (s *Ship) Layer() Layer { return LayerPlayer }
+
+/* A method that returns the layers the spaceship collides with */
+func (s *Ship) Mask() Layer { return LayerEnemy | LayerEnemyBullet | LayerPickup }
+
+/* A method that is called when the spaceship is hit by an enemy or an enemy bullet or touches a pickup */
+func (s *Ship) OnHit(w *World, other Collider) {
+	if other.Layer() == LayerPickup || s.HasEffect(PickupShield) {
+		return
+	}
+	s.takeHit(w)
+}
+
+/* A struct for the bullets */
+type Bullet struct {
+	Body
+	alive    bool
+	DirX     int   // How many columns the bullet moves every tick at DefaultTickRate, negative for going left
+	DirY     int   // How many times BulletClimbSpeed lines a second the bullet moves, negative for going up
+	Damage   int   // How much health the bullet takes when it hits
+	Piercing bool  // If the bullet keeps going after it hits something
+	Homing   bool  // If the bullet steers towards the closest enemy
+	Bomb     bool  // If the bullet blows up everything around it when it hits or its fuse runs out
+	Blast    bool  // If the bullet shows an explosion where it hits without hurting anything else
+	fuse     int   // Ticks left until a bomb blows up
+	prevX    int   // Where the bullet was before the last update so it can't skip over a ship
+	moved    mover // What is left of moving along the line
+	climbed  mover // What is left of moving up or down
+	steered  mover // What is left of steering towards an enemy
+	layer    Layer // Who shot the bullet
+	hits     map[Collider]bool
+}
+
+/* A function that creates a new bullet, bullets going right are the player's and bullets going left are the enemies' */
+func NewBullet(y, x int, dirX int) *Bullet {
+	layer := LayerPlayerBullet
+	if dirX < 0 {
+		layer = LayerEnemyBullet
+	}
+	return &Bullet{
+		Body:   Body{Y: y, X: x, Art: BulletArt, Tint: "red"},
+		alive:  true,
+		DirX:   dirX,
+		Damage: 1,
+		prevX:  x,
+		layer:  layer,
+	}
+}
+
+/* A function that updates the bullet */
+func (b *Bullet) Update(w *World) {
+	b.prevX = b.X
+	// Move the bullet in its direction as far as it goes in a tick at every tick rate
+	b.X += sign(b.DirX) * b.moved.step(abs(b.DirX)*DefaultTickRate, w.TickRate)
+	if b.Homing {
+		b.steer(w)
+	}
+	if b.DirY != 0 {
+		b.Y += sign(b.DirY) * b.climbed.step(abs(b.DirY)*BulletClimbSpeed, w.TickRate)
+	}
+	if b.Bomb {
+		b.fuse--
+		if b.fuse <= 0 {
+			b.explode(w)
+		}
+	}
+}
+
+/* A method that returns the hitbox of the bullet covering every cell it went through in the last update */
+func (b *Bullet) Hitbox() Hitbox {
+	x0, x1 := b.prevX, b.X
+	if x0 > x1 {
+		x0, x1 = x1, x0
+	}
+	return Hitbox{Y: b.Y, X: x0, H: 1, W: x1 - x0 + len([]rune(b.Art[0]))}
+}
+
+/* A method that returns the collision layer of the bullet */
+func (b *Bullet) Layer() Layer { return b.layer }
+
+/* A method that returns the layers the bullet collides with */
+func (b *Bullet) Mask() Layer {
+	if b.layer == LayerPlayerBullet {
+		return LayerEnemy
+	}
+	return LayerPlayer
+}
+
+/* A method that checks if the bullet can still hit something, a piercing bullet only hits everything once */
+func (b *Bullet) CanHit(other Collider) bool {
+	return !b.hits[other]
+}
+
+/* A method that is called when the bullet hits something */
+func (b *Bullet) OnHit(w *World, other Collider) {
+	if b.hits == nil {
+		b.hits = make(map[Collider]bool)
+	}
+	b.hits[other] = true
+	if b.Bomb {
+		b.explode(w)
+	} else if b.Blast {
+		w.blowUp(b.Y, b.X)
+	}
+	if !b.Piercing {
+		b.alive = false
+	}
+}
+
+/* A function that checks if a bullet has expired/died/offTheScreen */
+func (b *Bullet) Expired(w *World) bool {
+	return b.X >= w.Cols-1 || b.X < 0 || !b.alive
+}
+
+/* A struct for the Explosions animation */
+type Explosion struct {
+	Body
+	anim *Animator
+}
+
+/* A function that makes a new Explosion playing an animation */
+func NewExplosion(y, x int, animation *Animation) *Explosion {
+	anim := NewAnimator(animation)
+	return &Explosion{Body{Y: y - 1, X: x - 1, Art: anim.Art(), Tint: "red"}, anim}
+}
+
+/* A function that plays the explosion animation */
+func (e *Explosion) Update(w *World) {
+	e.anim.Update(w.TickRate)
+	e.Art = e.anim.Art()
+}
+
+/* A function that checks if the explosion animation is done */
+func (e *Explosion) Expired(w *World) bool {
+	return e.anim.Done
+}
+
+/* A struct for the animated flame behind the spaceship */
+type Thruster struct {
+	Body
+	ship *Ship
+	anim *Animator
+}
+
+/* A function that makes a new flame playing an animation behind a spaceship */
+func NewThruster(ship *Ship, animation *Animation) *Thruster {
+	t := &Thruster{Body{Tint: "yellow"}, ship, NewAnimator(animation)}
+	t.follow()
+	return t
+}
+
+/* A method that moves the flame to the back of the spaceship */
+func (t *Thruster) follow() {
+	t.Y = t.ship.Y + len(t.ship.Art)/2
+	t.X = t.ship.X - 1
+	t.Art = t.anim.Art()
+}
+
+/* A function that plays the flame animation and keeps it behind the spaceship */
+func (t *Thruster) Update(w *World) {
+	t.anim.Update(w.TickRate)
+	t.follow()
+}
+
+/* A function that checks if the spaceship of the flame is gone */
+func (t *Thruster) Expired(w *World) bool {
+	return t.ship.Expired(w)
+}
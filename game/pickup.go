@@ -0,0 +1,170 @@
+package game
+
+import "sort"
+
+/* PickupSeconds is how long a timed pickup lasts */
+const PickupSeconds = 10
+
+/* PickupLifeSeconds is how long a pickup stays in the world before it is gone */
+const PickupLifeSeconds = 10
+
+/* PickupSpeed is how many columns a second a pickup drifts to the left */
+const PickupSpeed = DefaultTickRate
+
+/* A kind of pickup */
+type PickupKind string
+
+/* The kinds of pickups, everything but health is a timed effect */
+const (
+	PickupHealth          PickupKind = "health"
+	PickupShield          PickupKind = "shield"
+	PickupRapidFire       PickupKind = "rapid_fire"
+	PickupSpreadShot      PickupKind = "spread_shot"
+	PickupScoreMultiplier PickupKind = "score_multiplier"
+	PickupSpeedBoost      PickupKind = "speed_boost"
+)
+
+/* PickupKinds is every kind of pickup */
+var PickupKinds = []PickupKind{PickupHealth, PickupShield, PickupRapidFire, PickupSpreadShot, PickupScoreMultiplier, PickupSpeedBoost}
+
+/* A struct for what a kind of pickup looks like */
+type pickupLook struct {
+	art   string
+	color string
+	label string
+}
+
+/* pickupLooks is what every kind of pickup looks like and what it is called on the HUD */
+var pickupLooks = map[PickupKind]pickupLook{
+	PickupHealth:          {"[H]", "green", "Health"},
+	PickupShield:          {"[S]", "blue", "Shield"},
+	PickupRapidFire:       {"[R]", "yellow", "Rapid fire"},
+	PickupSpreadShot:      {"[W]", "magenta", "Spread shot"},
+	PickupScoreMultiplier: {"[2]", "yellow", "Score x2"},
+	PickupSpeedBoost:      {"[>]", "white", "Speed boost"},
+}
+
+/* A method that returns the name of the pickup for the HUD */
+func (k PickupKind) Label() string {
+	return pickupLooks[k].label
+}
+
+/* A method that checks if the pickup is one that the game knows */
+func (k PickupKind) Valid() bool {
+	_, ok := pickupLooks[k]
+	return ok
+}
+
+/* A json structure for one entry of a drop table */
+type Drop struct {
+	Pickup PickupKind `json:"pickup"` /* The kind of pickup that is dropped */
+	Chance float64    `json:"chance"` /* The chance from 0 to 1 that it is dropped */
+}
+
+/* A method that rolls a drop table and returns the pickup that was dropped, the chances of the entries add up */
+func (w *World) rollDrop(table []Drop) (PickupKind, bool) {
+	roll := w.rng.Float64()
+	for _, d := range table {
+		if roll < d.Chance {
+			return d.Pickup, true
+		}
+		roll -= d.Chance
+	}
+	return "", false
+}
+
+/* A method that maybe drops a pickup where an enemy was destroyed using the drop table of the enemy or else of the level */
+func (w *World) dropPickup(table []Drop, y, x int) {
+	if len(table) == 0 {
+		table = w.Level.Drops
+	}
+	if kind, ok := w.rollDrop(table); ok {
+		w.Spawn(NewPickup(y, x, kind, w.TickRate))
+	}
+}
+
+/* A struct for a pickup that drifts with the starfield until it is collected or gone */
+type Pickup struct {
+	Body
+	Kind  PickupKind
+	alive bool
+	life  int   // Ticks left until the pickup is gone
+	moved mover // What is left of drifting a part of a column
+}
+
+/* A function that makes a new pickup for a world that is stepped tickRate times a second */
+func NewPickup(y, x int, kind PickupKind, tickRate int) *Pickup {
+	look := pickupLooks[kind]
+	return &Pickup{Body{Y: y, X: x, Art: []string{look.art}, Tint: look.color}, kind, true, PickupLifeSeconds * tickRate, mover{}}
+}
+
+/* A function that makes the pickup drift with the starfield */
+func (p *Pickup) Update(w *World) {
+	p.X -= p.moved.step(PickupSpeed, w.TickRate)
+	p.life--
+}
+
+/* A function that checks if the pickup was collected, went off the screen or has been around too long */
+func (p *Pickup) Expired(w *World) bool {
+	return !p.alive || p.life <= 0 || p.X+len(p.Art[0]) <= 0
+}
+
+/* A method that returns the collision layer of the pickup */
+func (p *Pickup) Layer() Layer { return LayerPickup }
+
+/* A method that returns the layers the pickup collides with */
+func (p *Pickup) Mask() Layer { return LayerPlayer }
+
+/* A method that is called when the spaceship collects the pickup */
+func (p *Pickup) OnHit(w *World, other Collider) {
+	p.alive = false
+	w.Ship.Collect(p.Kind, w.TickRate)
+}
+
+/* A method that gives the spaceship what a pickup does */
+func (s *Ship) Collect(kind PickupKind, tickRate int) {
+	if kind == PickupHealth {
+		if s.Life < s.MaxLife {
+			s.Life++
+		}
+		return
+	}
+	if s.Effects == nil {
+		s.Effects = make(map[PickupKind]int)
+	}
+	s.Effects[kind] = PickupSeconds * tickRate
+}
+
+/* A method that checks if a timed pickup is active on the spaceship */
+func (s *Ship) HasEffect(kind PickupKind) bool {
+	return s.Effects[kind] > 0
+}
+
+/* A method that counts down the timed pickups of the spaceship */
+func (s *Ship) updateEffects() {
+	for kind, ticks := range s.Effects {
+		if ticks <= 1 {
+			delete(s.Effects, kind)
+		} else {
+			s.Effects[kind] = ticks - 1
+		}
+	}
+}
+
+/* A struct for a timed pickup that is active and the seconds it has left */
+type ActiveEffect struct {
+	Kind    PickupKind
+	Seconds int
+}
+
+/* A method that returns the timed pickups of the spaceship in the same order every time */
+func (s *Ship) ActiveEffects(tickRate int) []ActiveEffect {
+	var effects []ActiveEffect
+	for kind, ticks := range s.Effects {
+		effects = append(effects, ActiveEffect{kind, (ticks + tickRate - 1) / tickRate})
+	}
+	sort.Slice(effects, func(i, j int) bool {
+		return effects[i].Kind < effects[j].Kind
+	})
+	return effects
+}
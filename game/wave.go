@@ -0,0 +1,98 @@
+package game
+
+import (
+	"math"
+	"sort"
+)
+
+/* A json structure for a wave of enemies in the timeline of a level */
+type Wave struct {
+	Time      float64 `json:"time"`      /* How many seconds into the level the wave spawns */
+	Enemy     string  `json:"enemy"`     /* The name of the enemy type in enemies.json */
+	Count     int     `json:"count"`     /* How many enemies are in the wave */
+	Formation string  `json:"formation"` /* How the enemies are placed: line, v, column or sine */
+	Y         int     `json:"y"`         /* The line the wave enters on, 0 is the middle of the screen */
+	Spacing   int     `json:"spacing"`   /* The space between two enemies, 0 picks one from the size of the enemy */
+}
+
+/* A struct that spawns the waves of a level when their time comes */
+type WaveScheduler struct {
+	waves []Wave
+	next  int
+}
+
+/* A function that makes a new wave scheduler for the waves of a level */
+func NewWaveScheduler(waves []Wave) *WaveScheduler {
+	sorted := append([]Wave(nil), waves...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Time < sorted[j].Time
+	})
+	return &WaveScheduler{waves: sorted}
+}
+
+/* A method that checks if every wave has spawned */
+func (s *WaveScheduler) Done() bool {
+	return s.next >= len(s.waves)
+}
+
+/* A method that spawns every wave whose time has come */
+func (s *WaveScheduler) Update(w *World, enemies EnemyTypes) {
+	for !s.Done() && float64(w.Ticks) >= s.waves[s.next].Time*float64(w.TickRate) {
+		s.spawn(w, s.waves[s.next], enemies)
+		s.next++
+	}
+}
+
+/* A method that spawns the enemies of one wave in its formation */
+func (s *WaveScheduler) spawn(w *World, wave Wave, enemies EnemyTypes) {
+	t, ok := enemies.Find(wave.Enemy)
+	if !ok {
+		t = &DefaultEnemyType
+	}
+	box := HitboxFor(0, 0, t.AsciiArt)
+	y := wave.Y
+	if y == 0 {
+		y = (w.Lines - box.H) / 2
+	}
+	x := w.Cols - 10
+	for i := 0; i < wave.Count; i++ {
+		dy, dx := formationOffset(wave, i, box)
+		w.Spawn(NewEnemyShip(clamp(y+dy, 1, w.Lines-box.H), x+dx, t, w.TickRate))
+	}
+}
+
+/* A function that returns where the enemy at index i of a wave is from the entry point of the wave */
+func formationOffset(wave Wave, i int, box Hitbox) (int, int) {
+	switch wave.Formation {
+	case "column":
+		spacing := wave.Spacing
+		if spacing == 0 {
+			spacing = box.H + 1
+		}
+		return (i - wave.Count/2) * spacing, 0
+	case "v":
+		spacing := wave.Spacing
+		if spacing == 0 {
+			spacing = box.W + 2
+		}
+		// The leader is at the tip and the others go back in two arms
+		arm := (i + 1) / 2
+		side := 1
+		if i%2 == 1 {
+			side = -1
+		}
+		return side * arm * (box.H + 1), arm * spacing
+	case "sine":
+		spacing := wave.Spacing
+		if spacing == 0 {
+			spacing = box.W + 2
+		}
+		return int(math.Round(float64(box.H+1) * math.Sin(float64(i)*math.Pi/2))), i * spacing
+	default:
+		spacing := wave.Spacing
+		if spacing == 0 {
+			spacing = box.W + 2
+		}
+		return 0, i * spacing
+	}
+}
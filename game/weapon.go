@@ -0,0 +1,225 @@
+package game
+
+import "math"
+
+/* BombFuseSeconds is how long a bomb flies before it blows up on its own */
+const BombFuseSeconds = 1.5
+
+/* BombRadius is how far a bomb blast reaches from where it blows up, in lines and twice as many columns */
+const BombRadius = 3
+
+/* A json structure for a weapon of a character */
+type Weapon struct {
+	Name       string  `json:"name"`       /* The name shown on the HUD */
+	Kind       string  `json:"kind"`       /* How it shoots: blaster, laser, homing or bomb */
+	FireRate   float64 `json:"fire_rate"`  /* How many times it can shoot in a second */
+	Projectile string  `json:"projectile"` /* What the projectiles look like */
+	Color      string  `json:"color"`      /* The colour of the projectiles */
+	Damage     int     `json:"damage"`     /* How much health a projectile takes */
+	Spread     int     `json:"spread"`     /* How many extra projectiles go up and down at an angle on each side */
+	Piercing   bool    `json:"piercing"`   /* If the projectiles go through what they hit */
+	Speed      int     `json:"speed"`      /* How many columns the projectiles move every tick at DefaultTickRate */
+	Ammo       int     `json:"ammo"`       /* How many times it can shoot in a level, 0 never runs out */
+}
+
+/* DefaultWeapon is the weapon of a character that has no weapons */
+var DefaultWeapon = Weapon{
+	Name:       "Blaster",
+	Kind:       "blaster",
+	FireRate:   6,
+	Projectile: "-",
+	Color:      "red",
+	Damage:     1,
+	Speed:      1,
+}
+
+/* A struct for a weapon on the spaceship with its cooldown and the ammo it has left */
+type WeaponState struct {
+	Weapon   Weapon
+	Cooldown int // Ticks left until it can shoot again
+	Reload   int // Ticks of the last cooldown, used to show how far along the cooldown is
+	Ammo     int // The ammo left if the weapon has ammo
+}
+
+/* A function that makes the state of a weapon with full ammo and no cooldown */
+func NewWeaponState(weapon Weapon) *WeaponState {
+	return &WeaponState{Weapon: weapon, Ammo: weapon.Ammo}
+}
+
+/* A method that checks if the weapon ran out of ammo */
+func (ws *WeaponState) Empty() bool {
+	return ws.Weapon.Ammo > 0 && ws.Ammo <= 0
+}
+
+/* A method that checks if the weapon can shoot right now */
+func (ws *WeaponState) Ready() bool {
+	return ws.Cooldown <= 0 && !ws.Empty()
+}
+
+/* A method that returns the weapon being used by the spaceship */
+func (s *Ship) Weapon() *WeaponState {
+	return s.Weapons[s.Active]
+}
+
+/* A method that switches the spaceship to its next weapon */
+func (s *Ship) CycleWeapon() {
+	s.Active = (s.Active + 1) % len(s.Weapons)
+}
+
+/* A method that counts down the cooldowns of every weapon of the spaceship */
+func (s *Ship) coolWeapons() {
+	for _, ws := range s.Weapons {
+		ws.Cooldown--
+	}
+	if s.Secondary != nil {
+		s.Secondary.Cooldown--
+	}
+}
+
+/* A method that shoots a weapon of the spaceship if it is ready, the pickups that are active change how it shoots */
+func (w *World) fireWeapon(ws *WeaponState) {
+	if !ws.Ready() {
+		return
+	}
+	s := w.Ship
+	weapon := ws.Weapon
+	rate := weapon.FireRate
+	if rate <= 0 {
+		rate = DefaultWeapon.FireRate
+	}
+	rate *= s.FireRate
+	if s.HasEffect(PickupRapidFire) {
+		rate *= 2
+	}
+	ws.Reload = int(math.Max(1, math.Round(float64(w.TickRate)/rate)))
+	ws.Cooldown = ws.Reload
+	if weapon.Ammo > 0 {
+		ws.Ammo--
+	}
+	w.emit(SoundShoot)
+
+	box := s.Body.Hitbox() // Shoot from the front of the ascii art even if the hitbox is smaller
+	mid := s.Y + box.H/2
+	rows := []int{mid}
+	if weapon.Kind == "blaster" || weapon.Kind == "" {
+		rows = []int{mid - 1, mid + 1}
+	}
+	spread := weapon.Spread
+	if s.HasEffect(PickupSpreadShot) {
+		spread++
+	}
+	for _, y := range rows {
+		w.Spawn(w.newProjectile(weapon, y, s.X+box.W, 0))
+	}
+	for k := 1; k <= spread; k++ {
+		w.Spawn(w.newProjectile(weapon, mid, s.X+box.W, -k))
+		w.Spawn(w.newProjectile(weapon, mid, s.X+box.W, k))
+	}
+}
+
+/* A method that makes one projectile of a weapon going up or down by dirY */
+func (w *World) newProjectile(weapon Weapon, y, x, dirY int) *Bullet {
+	speed := weapon.Speed
+	if speed <= 0 {
+		speed = 1
+	}
+	b := NewBullet(y, x, speed)
+	b.DirY = dirY
+	b.Damage = weapon.Damage
+	if b.Damage <= 0 {
+		b.Damage = 1
+	}
+	b.Damage *= w.Ship.Firepower
+	b.Piercing = weapon.Piercing
+	if weapon.Projectile != "" {
+		b.Art = []string{weapon.Projectile}
+	}
+	if weapon.Color != "" {
+		b.Tint = weapon.Color
+	}
+	switch weapon.Kind {
+	case "laser":
+		b.Piercing = true
+	case "homing":
+		b.Homing = true
+	case "bomb":
+		b.Bomb = true
+		b.fuse = int(BombFuseSeconds * float64(w.TickRate))
+	}
+	return b
+}
+
+/* A method that moves a homing bullet towards the closest enemy in front of it at HomingSpeed */
+func (b *Bullet) steer(w *World) {
+	lines := b.steered.step(HomingSpeed, w.TickRate)
+	if lines == 0 {
+		return
+	}
+	best, bestDist := -1, 0
+	for _, ob := range w.Objects {
+		c, ok := ob.(Collider)
+		if !ok || c.Layer() != LayerEnemy || ob.Expired(w) {
+			continue
+		}
+		box := c.Hitbox()
+		if box.X+box.W < b.X {
+			continue
+		}
+		cy := box.Y + box.H/2
+		dist := abs(box.X-b.X) + 2*abs(cy-b.Y)
+		if best == -1 || dist < bestDist {
+			best, bestDist = cy, dist
+		}
+	}
+	if best > b.Y {
+		b.Y += min(lines, best-b.Y)
+	} else if best != -1 && best < b.Y {
+		b.Y -= min(lines, b.Y-best)
+	}
+}
+
+/* A method that blows up a bomb and hurts every enemy in the blast that the bomb didn't hit already */
+func (b *Bullet) explode(w *World) {
+	if !b.alive {
+		return
+	}
+	b.alive = false
+	w.blowUp(b.Y, b.X)
+	blast := Hitbox{Y: b.Y - BombRadius, X: b.X - 2*BombRadius, H: 2*BombRadius + 1, W: 4*BombRadius + 1}
+	for _, ob := range w.Objects {
+		c, ok := ob.(Collider)
+		if !ok || c.Layer() != LayerEnemy || ob.Expired(w) || b.hits[c] {
+			continue
+		}
+		if c.Hitbox().Overlaps(blast) {
+			c.OnHit(w, b)
+		}
+	}
+}
+
+/* A function that returns how much health a collider takes when it hits something */
+func DamageOf(c Collider) int {
+	if b, ok := c.(*Bullet); ok && b.Damage > 0 {
+		return b.Damage
+	}
+	return 1
+}
+
+/* A function that returns the absolute value of n */
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+/* A function that returns -1 for a negative n, 1 for a positive n and 0 for 0 */
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,285 @@
+/* Package game is the headless simulation of space-glide, it knows nothing about the terminal and only has to be stepped and rendered */
+package game
+
+import "math/rand"
+
+/* DefaultTickRate is how many simulation steps there are in one second unless a world is given another tick rate */
+const DefaultTickRate = 16
+
+/* EnemySpawnSeconds is how many seconds there are between two enemy ships spawning */
+const EnemySpawnSeconds = 2
+
+/* EnemyScore is how many points destroying an enemy ship is worth */
+const EnemyScore = 100
+
+/* The sounds the world asks to be played, the front end decides what they sound like */
+const (
+	SoundShoot     = "shoot"
+	SoundExplosion = "explosion"
+	SoundHit       = "hit"
+)
+
+/* How a run of a level ended */
+type Outcome int
+
+/* The ways a run of a level can end */
+const (
+	Running   Outcome = iota // The level is still being played
+	Victory                  // The score target was reached or the enemy quota was destroyed in time
+	TimeUp                   // The timer ran out
+	Destroyed                // The spaceship ran out of life
+)
+
+/* A method that returns a short description of the outcome */
+func (o Outcome) String() string {
+	switch o {
+	case Victory:
+		return "Level complete"
+	case TimeUp:
+		return "Time is up"
+	case Destroyed:
+		return "Spaceship destroyed"
+	default:
+		return "Running"
+	}
+}
+
+/* A struct holding what the player pressed during one step */
+type Input struct {
+	Up        bool
+	Down      bool
+	Left      bool
+	Right     bool
+	Shoot     bool
+	Secondary bool // Shoot the secondary weapon
+	Cycle     bool // Switch to the next weapon
+}
+
+/* A method that combines two inputs so that everything pressed in either of them is pressed */
+func (in Input) Or(other Input) Input {
+	return Input{
+		Up:        in.Up || other.Up,
+		Down:      in.Down || other.Down,
+		Left:      in.Left || other.Left,
+		Right:     in.Right || other.Right,
+		Shoot:     in.Shoot || other.Shoot,
+		Secondary: in.Secondary || other.Secondary,
+		Cycle:     in.Cycle || other.Cycle,
+	}
+}
+
+/* A struct for the whole game world */
+type World struct {
+	Lines, Cols int      // The size of the screen
+	Ship        *Ship    // The spaceship of the player
+	Objects     []Object // All of the objects including the spaceship
+	Level       Level    // The level being played
+	TimeLeft    int      // The seconds left on the timer
+	Scroll      int      // How far the starfield has scrolled
+	Kills       int      // How many enemy ships the player destroyed
+	Ticks       int      // How many steps have been done
+	TickRate    int      // How many steps there are in one second
+	Outcome     Outcome  // How the run ended or Running if it didn't end yet
+	Seed        int64    // The seed all of the randomness of the world comes from
+	rng         *rand.Rand
+	enemyTypes  []*EnemyType
+	enemies     EnemyTypes
+	waves       *WaveScheduler
+	Boss        *Boss // The boss once the boss fight of the level started
+	bossDesign  BossDesign
+	Sounds      []string // The sounds asked for during the last step in the order they happened
+}
+
+/* A struct for everything a new world is made from */
+type Options struct {
+	Lines, Cols int         // The size of the screen
+	Character   *Character  // The character the player chose
+	Level       Level       // The level to play
+	Enemies     EnemyTypes  // All of the enemy types the level can spawn from
+	Seed        int64       // The seed of the world, the same seed always gives the same world
+	TickRate    int         // How many times a second the world is stepped, DefaultTickRate if it is 0
+	Boss        *BossDesign // What the boss looks like, DefaultBossDesign if it is nil
+}
+
+/* A function that makes a new world for a level */
+func NewWorld(opts Options) *World {
+	if opts.TickRate <= 0 {
+		opts.TickRate = DefaultTickRate
+	}
+	w := &World{
+		TickRate:   opts.TickRate,
+		Lines:      opts.Lines,
+		Cols:       opts.Cols,
+		Level:      opts.Level,
+		TimeLeft:   opts.Level.Time,
+		Seed:       opts.Seed,
+		rng:        rand.New(rand.NewSource(opts.Seed)),
+		enemyTypes: opts.Enemies.ForLevel(opts.Level),
+		enemies:    opts.Enemies,
+		waves:      NewWaveScheduler(opts.Level.Waves),
+		bossDesign: DefaultBossDesign,
+	}
+	if opts.Boss != nil {
+		w.bossDesign = *opts.Boss
+	}
+	w.Ship = NewShip(opts.Lines/2, 5, opts.Character)
+	w.Spawn(w.Ship)
+	w.Spawn(NewThruster(w.Ship, w.bossDesign.thruster()))
+	return w
+}
+
+/* A method that adds an object to the world */
+func (w *World) Spawn(ob Object) {
+	w.Objects = append(w.Objects, ob)
+}
+
+/* A method that asks for a sound to be played */
+func (w *World) emit(sound string) {
+	w.Sounds = append(w.Sounds, sound)
+}
+
+/* A method that spawns an explosion and asks for the sound of it */
+func (w *World) blowUp(y, x int) {
+	w.Spawn(NewExplosion(y, x, w.bossDesign.explosion()))
+	w.emit(SoundExplosion)
+}
+
+/* A method that checks if the run is over */
+func (w *World) Over() bool {
+	return w.Outcome != Running
+}
+
+/* A method that advances the world by one tick using the input of the player */
+func (w *World) Step(in Input) {
+	if w.Over() {
+		return
+	}
+	w.Ticks++
+	w.Sounds = nil
+	w.handleInput(in)
+
+	w.Scroll++
+	w.updateObjects()
+	// Levels without waves fall back to spawning random enemies
+	switch {
+	case w.Boss != nil:
+		// Nothing else spawns during the boss fight, the boss spawns its own minions
+	case len(w.Level.Waves) > 0:
+		w.waves.Update(w, w.enemies)
+	case w.Ticks%(EnemySpawnSeconds*w.TickRate) == 0:
+		t := w.enemyTypes[w.rng.Intn(len(w.enemyTypes))] // Randomly select the type of the enemy ship
+		ey := w.rng.Intn(w.Lines-4) + 2                  // Randomly select the y position for the enemy ship
+		ex := w.Cols - 10                                // Set the x position to the right edge of the screen
+		w.Spawn(NewEnemyShip(ey, ex, t, w.TickRate))
+	}
+	if w.Ticks%w.TickRate == 0 {
+		w.TimeLeft--
+	}
+	w.Outcome = w.checkOutcome()
+}
+
+/* A method that checks if the score target was reached or the enemy quota was destroyed */
+func (w *World) targetReached() bool {
+	return w.Level.Score > 0 && w.Ship.Score >= w.Level.Score ||
+		w.Level.Enemies > 0 && w.Kills >= w.Level.Enemies
+}
+
+/* A method that applies the rules of the level and returns how the run stands, levels with a boss start the boss fight once the target is reached */
+func (w *World) checkOutcome() Outcome {
+	switch {
+	case w.Ship.Expired(w):
+		return Destroyed
+	case w.Boss != nil && w.Boss.Defeated():
+		return Victory
+	case w.Boss == nil && w.targetReached():
+		if !w.Level.Boss {
+			return Victory
+		}
+		w.Boss = NewBoss(w, w.bossDesign)
+		w.Spawn(w.Boss)
+		return Running
+	case w.TimeLeft <= 0:
+		return TimeUp
+	default:
+		return Running
+	}
+}
+
+/* A method that moves the spaceship and shoots using the input of the player */
+func (w *World) handleInput(in Input) {
+	s := w.Ship
+	speed := s.Speed
+	if s.HasEffect(PickupSpeedBoost) {
+		speed++
+	}
+	// A key press moves the spaceship speed cells at speed cells every tick at DefaultTickRate, so it goes as far and as fast at every tick rate
+	if in.Left || in.Right || in.Up || in.Down {
+		s.moveX, s.moveY = direction(in.Left, in.Right), direction(in.Up, in.Down)
+		// Presses are only seen once a tick, so slower tick rates move further for every press
+		s.moveCells = speed * max(1, DefaultTickRate/w.TickRate)
+	}
+	if s.moveCells > 0 {
+		cells := min(s.moveCells, s.moved.step(speed*DefaultTickRate, w.TickRate))
+		s.moveCells -= cells
+		s.X += s.moveX * cells
+		s.Y += s.moveY * cells
+	}
+	s.X = clamp(s.X, 2, w.Cols-3)
+	s.Y = clamp(s.Y, 2, w.Lines-4)
+	if in.Cycle {
+		s.CycleWeapon()
+	}
+	if in.Shoot {
+		w.fireWeapon(s.Weapon())
+	}
+	if in.Secondary && s.Secondary != nil {
+		w.fireWeapon(s.Secondary)
+	}
+}
+
+/* A method that adds points to the score, doubled while the score multiplier is active */
+func (w *World) AddScore(points int) {
+	if w.Ship.HasEffect(PickupScoreMultiplier) {
+		points *= 2
+	}
+	w.Ship.Score += points
+}
+
+/* A method that updates the objects, handles the collisions and removes the expired objects */
+func (w *World) updateObjects() {
+	end := len(w.Objects)
+	for _, ob := range w.Objects[:end] {
+		ob.Update(w)
+	}
+	w.collide()
+	tmp := make([]Object, 0, len(w.Objects))
+	for _, ob := range w.Objects {
+		if ob == Object(w.Ship) || !ob.Expired(w) {
+			tmp = append(tmp, ob)
+		}
+	}
+	w.Objects = tmp
+}
+
+/* A function that returns the direction of two opposite keys, -1 for the negative one, 1 for the positive one and 0 for both or none */
+func direction(negative, positive bool) int {
+	d := 0
+	if negative {
+		d--
+	}
+	if positive {
+		d++
+	}
+	return d
+}
+
+/* A function that keeps n between lo and hi */
+func clamp(n, lo, hi int) int {
+	if n < lo {
+		return lo
+	}
+	if n > hi {
+		return hi
+	}
+	return n
+}
@@ -0,0 +1,167 @@
+package game
+
+import (
+	"math/rand"
+	"testing"
+)
+
+/* target is an enemy type that neither moves nor shoots so tests can aim at it */
+var target = &EnemyType{
+	Name:       "target",
+	AsciiArt:   EnemyArt,
+	Health:     1,
+	ScoreValue: 100,
+	Movement:   "straight",
+}
+
+/* noSpawns is a wave far in the future so that levels in the tests don't spawn random enemies */
+var noSpawns = []Wave{{Time: 1000}}
+
+/* A function that makes a small world for a level with the default spaceship in the middle of the left edge */
+func testWorld(level Level) *World {
+	return NewWorld(Options{Lines: 30, Cols: 100, Character: &Character{Name: "test"}, Level: level, Seed: 1})
+}
+
+/* A function that steps a world with no input n times or until it is over */
+func stepN(w *World, n int) {
+	for i := 0; i < n && !w.Over(); i++ {
+		w.Step(Input{})
+	}
+}
+
+/* A function that returns the enemy ships left in a world */
+func enemiesIn(w *World) []*EnemyShip {
+	var enemies []*EnemyShip
+	for _, ob := range w.Objects {
+		if e, ok := ob.(*EnemyShip); ok {
+			enemies = append(enemies, e)
+		}
+	}
+	return enemies
+}
+
+func TestPlayerBulletKillsEnemy(t *testing.T) {
+	w := testWorld(Level{Time: 100, Enemies: 5, Waves: noSpawns})
+	w.Spawn(NewEnemyShip(w.Ship.Y-1, 40, target, w.TickRate))
+	w.Step(Input{Shoot: true})
+	stepN(w, 60)
+
+	if got := len(enemiesIn(w)); got != 0 {
+		t.Fatalf("%d enemies left, want the enemy to be destroyed", got)
+	}
+	if w.Ship.Score != target.ScoreValue {
+		t.Errorf("score is %d, want %d", w.Ship.Score, target.ScoreValue)
+	}
+	if w.Kills != 1 {
+		t.Errorf("kills are %d, want 1", w.Kills)
+	}
+}
+
+func TestEnemyBulletDoesNotHurtEnemies(t *testing.T) {
+	w := testWorld(Level{Time: 100, Enemies: 5, Waves: noSpawns})
+	enemy := NewEnemyShip(2, 40, &EnemyType{Name: "tough", AsciiArt: EnemyArt, Health: 3}, w.TickRate)
+	w.Spawn(enemy)
+	bullet := NewBullet(4, 60, -1)
+	w.Spawn(bullet)
+	stepN(w, 30)
+
+	if bullet.X >= enemy.X || bullet.Expired(w) {
+		t.Fatalf("the bullet is at %d and expired is %v, want it to have flown through the enemy at %d", bullet.X, bullet.Expired(w), enemy.X)
+	}
+
+	if enemy.Health != 3 || enemy.Expired(w) {
+		t.Errorf("enemy has %d health and expired is %v, want it untouched", enemy.Health, enemy.Expired(w))
+	}
+	if w.Ship.Score != 0 {
+		t.Errorf("score is %d, want 0", w.Ship.Score)
+	}
+}
+
+func TestVictory(t *testing.T) {
+	tests := []struct {
+		name  string
+		level Level
+	}{
+		{"enemy quota", Level{Time: 100, Enemies: 1, Waves: noSpawns}},
+		{"score target", Level{Time: 100, Score: target.ScoreValue, Waves: noSpawns}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := testWorld(tt.level)
+			w.Spawn(NewEnemyShip(w.Ship.Y-1, 40, target, w.TickRate))
+			w.Step(Input{Shoot: true})
+			if w.Over() {
+				t.Fatalf("the run ended straight away with %q", w.Outcome)
+			}
+			stepN(w, 60)
+			if w.Outcome != Victory {
+				t.Errorf("outcome is %q, want %q", w.Outcome, Victory)
+			}
+		})
+	}
+}
+
+func TestTimeUp(t *testing.T) {
+	w := testWorld(Level{Time: 2, Enemies: 5, Waves: noSpawns})
+	stepN(w, 2*w.TickRate-1)
+	if w.Over() {
+		t.Fatalf("the run ended after %d ticks with %q, want it to still be running", w.Ticks, w.Outcome)
+	}
+	w.Step(Input{})
+	if w.Outcome != TimeUp {
+		t.Errorf("outcome is %q, want %q", w.Outcome, TimeUp)
+	}
+}
+
+func TestSameSeedSameRun(t *testing.T) {
+	play := func() *World {
+		w := NewWorld(Options{
+			Lines:     30,
+			Cols:      100,
+			Character: &Character{Name: "test"},
+			Level:     Level{Time: 30, Enemies: 10},
+			Enemies:   EnemyTypes{Enemies: []EnemyType{DefaultEnemyType}},
+			Seed:      42,
+		})
+		rng := rand.New(rand.NewSource(7))
+		for !w.Over() {
+			w.Step(Input{
+				Up:    rng.Intn(3) == 0,
+				Down:  rng.Intn(3) == 0,
+				Shoot: rng.Intn(2) == 0,
+			})
+		}
+		return w
+	}
+	a, b := play(), play()
+	if a.Outcome != b.Outcome || a.Ticks != b.Ticks || a.Ship.Score != b.Ship.Score || a.Kills != b.Kills || a.Ship.Life != b.Ship.Life {
+		t.Errorf("runs differ: %q %d ticks %d points %d kills %d life and %q %d ticks %d points %d kills %d life",
+			a.Outcome, a.Ticks, a.Ship.Score, a.Kills, a.Ship.Life, b.Outcome, b.Ticks, b.Ship.Score, b.Kills, b.Ship.Life)
+	}
+}
+
+func TestSpeedsDontDependOnTickRate(t *testing.T) {
+	type positions struct{ shipX, shipY, bulletX, bulletY, enemyX, enemyY, pickupX int }
+	run := func(tickRate int) positions {
+		w := NewWorld(Options{Lines: 30, Cols: 200, Character: &Character{Name: "test"}, Level: Level{Time: 100, Enemies: 5, Waves: noSpawns}, TickRate: tickRate, Seed: 1})
+		bullet := NewBullet(20, 10, 2)
+		bullet.DirY = -1
+		zigzag := &EnemyType{Name: "zigzag", AsciiArt: EnemyArt, Health: 1, Speed: 8, Movement: "zigzag"}
+		enemy := NewEnemyShip(5, 150, zigzag, tickRate)
+		pickup := NewPickup(25, 100, PickupHealth, tickRate)
+		w.Spawn(bullet)
+		w.Spawn(enemy)
+		w.Spawn(pickup)
+		// Every key press moves the spaceship as far, the presses are a default tick apart
+		for i := 0; i < tickRate; i++ {
+			w.Step(Input{Right: i%(tickRate/DefaultTickRate) == 0 && i < tickRate/2, Down: i == 0})
+		}
+		return positions{w.Ship.X, w.Ship.Y, bullet.X, bullet.Y, enemy.X, enemy.Y, pickup.X}
+	}
+	want := run(DefaultTickRate)
+	for _, tickRate := range []int{2 * DefaultTickRate, 4 * DefaultTickRate} {
+		if got := run(tickRate); got != want {
+			t.Errorf("after a second at %d ticks a second the positions are %+v, want %+v like at %d", tickRate, got, want, DefaultTickRate)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+/* Package mods finds content packs and lays them over the base content of space-glide, a pack is a directory or a zip file with a manifest.json and any of the json, design and audio files of the game */
+package mods
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+/* ManifestFile is the name of the manifest at the root of a content pack */
+const ManifestFile = "manifest.json"
+
+/* A json structure for the manifest of a content pack */
+type Manifest struct {
+	Name        string   `json:"name"`              /* The name of the pack shown in the menu, it must be unique */
+	Version     string   `json:"version"`           /* The version of the pack */
+	Author      string   `json:"author"`            /* Who made the pack */
+	Description string   `json:"description"`       /* What the pack changes */
+	Replace     []string `json:"replace,omitempty"` /* The json files of the pack that replace the base ones instead of being merged into them */
+}
+
+/* A struct for a content pack that was found */
+type Pack struct {
+	Manifest
+	Path string // The directory or the zip file of the pack
+	FS   fs.FS  // The files of the pack, the manifest is at the root
+}
+
+/* A function that opens a content pack from a directory or a zip file, the files can also be in a single directory inside the zip file */
+func Open(path string) (*Pack, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	var fsys fs.FS
+	if info.IsDir() {
+		fsys = os.DirFS(path)
+	} else {
+		// The zip file stays open for as long as the game runs
+		reader, err := zip.OpenReader(path)
+		if err != nil {
+			return nil, err
+		}
+		fsys = &reader.Reader
+		if _, err := fs.Stat(fsys, ManifestFile); err != nil {
+			if fsys, err = singleSubdir(fsys); err != nil {
+				reader.Close()
+				return nil, err
+			}
+		}
+	}
+	data, err := fs.ReadFile(fsys, ManifestFile)
+	if err != nil {
+		return nil, err
+	}
+	pack := &Pack{Path: path, FS: fsys}
+	if err := json.Unmarshal(data, &pack.Manifest); err != nil {
+		return nil, fmt.Errorf("%s: %w", ManifestFile, err)
+	}
+	if pack.Name == "" {
+		return nil, fmt.Errorf("%s: the pack has no name", ManifestFile)
+	}
+	return pack, nil
+}
+
+/* A function that returns the only directory at the root of a zip file as a file system */
+func singleSubdir(fsys fs.FS) (fs.FS, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) != 1 || !entries[0].IsDir() {
+		return nil, errors.New("no " + ManifestFile + " in the zip file")
+	}
+	return fs.Sub(fsys, entries[0].Name())
+}
+
+/* A function that finds the content packs in the mods directories sorted by name, a name found twice keeps the first pack and the packs that can't be opened are returned as errors */
+func Discover(dirs []string) ([]*Pack, []error) {
+	var packs []*Pack
+	var errs []error
+	seen := make(map[string]bool)
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			if !errors.Is(err, fs.ErrNotExist) {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() && !strings.EqualFold(filepath.Ext(entry.Name()), ".zip") {
+				continue
+			}
+			path := filepath.Join(dir, entry.Name())
+			pack, err := Open(path)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("content pack %s: %w", path, err))
+				continue
+			}
+			if seen[pack.Name] {
+				continue
+			}
+			seen[pack.Name] = true
+			packs = append(packs, pack)
+		}
+	}
+	sort.Slice(packs, func(i, j int) bool { return packs[i].Name < packs[j].Name })
+	return packs, errs
+}
+
+/* A function that returns the content pack with a name */
+func Find(packs []*Pack, name string) (*Pack, bool) {
+	for _, pack := range packs {
+		if pack.Name == name {
+			return pack, true
+		}
+	}
+	return nil, false
+}
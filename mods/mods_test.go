@@ -0,0 +1,137 @@
+package mods
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+)
+
+/* base is the content the packs of the tests are laid over */
+var base = fstest.MapFS{
+	"json/levels.json":       {Data: []byte(`{"levels": [{"number": 1, "time": 60}, {"number": 2, "time": 90}]}`)},
+	"json/enemies.json":      {Data: []byte(`{"enemies": [{"name": "grunt", "health": 1}]}`)},
+	"design/main_menu.txt":   {Data: []byte("SPACE GLIDE\n")},
+	"audio/SelectOption.mp3": {Data: []byte("base sound")},
+}
+
+/* A function that writes the files of a content pack to a directory */
+func writePack(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, data := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+/* A function that reads the levels of a file system as a map from the number to the time */
+func levelTimes(t *testing.T, fsys fs.FS) map[int]int {
+	t.Helper()
+	data, err := fs.ReadFile(fsys, "json/levels.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var levels struct {
+		Levels []struct {
+			Number int `json:"number"`
+			Time   int `json:"time"`
+		} `json:"levels"`
+	}
+	if err := json.Unmarshal(data, &levels); err != nil {
+		t.Fatal(err)
+	}
+	times := make(map[int]int)
+	for _, l := range levels.Levels {
+		times[l.Number] = l.Time
+	}
+	return times
+}
+
+func TestOverlayMergesAndReplaces(t *testing.T) {
+	dir := t.TempDir()
+	writePack(t, dir, map[string]string{
+		ManifestFile:           `{"name": "hard"}`,
+		"json/levels.json":     `{"levels": [{"number": 2, "time": 30}, {"number": 3, "time": 45}]}`,
+		"design/main_menu.txt": "HARD GLIDE\n",
+	})
+	pack, err := Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	overlay := NewOverlay(base, pack)
+
+	want := map[int]int{1: 60, 2: 30, 3: 45}
+	if got := levelTimes(t, overlay); len(got) != len(want) || got[1] != want[1] || got[2] != want[2] || got[3] != want[3] {
+		t.Errorf("the merged levels have the times %v, want %v", got, want)
+	}
+	if data, err := fs.ReadFile(overlay, "design/main_menu.txt"); err != nil || string(data) != "HARD GLIDE\n" {
+		t.Errorf("the main menu is %q (%v), want the one of the pack", data, err)
+	}
+	if data, err := fs.ReadFile(overlay, "audio/SelectOption.mp3"); err != nil || string(data) != "base sound" {
+		t.Errorf("the sound is %q (%v), want the base one the pack doesn't change", data, err)
+	}
+}
+
+func TestOverlayReplaceInManifest(t *testing.T) {
+	dir := t.TempDir()
+	writePack(t, dir, map[string]string{
+		ManifestFile:       `{"name": "short", "replace": ["json/levels.json"]}`,
+		"json/levels.json": `{"levels": [{"number": 1, "time": 10}]}`,
+	})
+	pack, err := Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := levelTimes(t, NewOverlay(base, pack)); len(got) != 1 || got[1] != 10 {
+		t.Errorf("the levels have the times %v, want only the level of the pack", got)
+	}
+}
+
+func TestOpenZipPack(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "sounds.zip")
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zw := zip.NewWriter(file)
+	// The files are inside a single directory like zip files made from a folder
+	for name, data := range map[string]string{
+		"sounds/" + ManifestFile:        `{"name": "sounds", "version": "1.0"}`,
+		"sounds/audio/SelectOption.mp3": "pack sound",
+	} {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write([]byte(data))
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	file.Close()
+
+	packs, errs := Discover([]string{dir, filepath.Join(dir, "missing")})
+	if len(errs) != 0 || len(packs) != 1 {
+		t.Fatalf("found the packs %v with the errors %v, want the zip pack", packs, errs)
+	}
+	pack, ok := Find(packs, "sounds")
+	if !ok || pack.Version != "1.0" || pack.Path != path {
+		t.Fatalf("found %+v, want the sounds pack from %s", pack, path)
+	}
+	overlay := NewOverlay(base, pack)
+	if data, err := fs.ReadFile(overlay, "audio/SelectOption.mp3"); err != nil || string(data) != "pack sound" {
+		t.Errorf("the sound is %q (%v), want the one of the pack", data, err)
+	}
+	if got := levelTimes(t, overlay); len(got) != 2 {
+		t.Errorf("the levels have the times %v, want the base ones", got)
+	}
+}
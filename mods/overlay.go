@@ -0,0 +1,132 @@
+package mods
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"time"
+)
+
+/* A struct for how a json file of the game is merged, the entries of the list are matched by the key */
+type mergeRule struct {
+	List string // The field holding the list like "levels"
+	Key  string // The field of an entry that identifies it like "number"
+}
+
+/* mergeRules are the json files that are merged instead of being replaced, an entry of the pack replaces the base entry with the same key and the other entries are appended */
+var mergeRules = map[string]mergeRule{
+	"json/characters.json": {List: "characters", Key: "name"},
+	"json/levels.json":     {List: "levels", Key: "number"},
+	"json/enemies.json":    {List: "enemies", Key: "name"},
+}
+
+/* A struct for the base content with a content pack laid over it, it is a file system like the base content */
+type Overlay struct {
+	base   fs.FS
+	pack   *Pack
+	merged map[string][]byte
+}
+
+/* A function that lays a content pack over the base content, the json files are merged when they are opened */
+func NewOverlay(base fs.FS, pack *Pack) *Overlay {
+	return &Overlay{base: base, pack: pack, merged: make(map[string][]byte)}
+}
+
+/* A method that opens a file from the content pack if it has it and from the base content if it doesn't, the json files of both are merged */
+func (o *Overlay) Open(name string) (fs.File, error) {
+	rule, ok := mergeRules[name]
+	if !ok || o.replaces(name) {
+		file, err := o.pack.FS.Open(name)
+		if err == nil || !errors.Is(err, fs.ErrNotExist) {
+			return file, err
+		}
+		return o.base.Open(name)
+	}
+	data, ok := o.merged[name]
+	if !ok {
+		var err error
+		if data, err = o.merge(name, rule); err != nil {
+			return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+		}
+		o.merged[name] = data
+	}
+	return &memFile{Reader: bytes.NewReader(data), name: name, size: int64(len(data))}, nil
+}
+
+/* A method that checks if the manifest says that a file replaces the base one */
+func (o *Overlay) replaces(name string) bool {
+	for _, r := range o.pack.Replace {
+		if r == name {
+			return true
+		}
+	}
+	return false
+}
+
+/* A method that merges the entries of a json file of the pack into the base one */
+func (o *Overlay) merge(name string, rule mergeRule) ([]byte, error) {
+	baseData, err := fs.ReadFile(o.base, name)
+	if err != nil {
+		return nil, err
+	}
+	packData, err := fs.ReadFile(o.pack.FS, name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return baseData, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var base, pack map[string]json.RawMessage
+	if err := json.Unmarshal(baseData, &base); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(packData, &pack); err != nil {
+		return nil, fmt.Errorf("content pack %s: %w", o.pack.Name, err)
+	}
+	var baseList, packList []map[string]json.RawMessage
+	if err := json.Unmarshal(base[rule.List], &baseList); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(pack[rule.List], &packList); err != nil {
+		return nil, fmt.Errorf("content pack %s: %w", o.pack.Name, err)
+	}
+	for _, entry := range packList {
+		key := string(entry[rule.Key])
+		replaced := false
+		for i := range baseList {
+			if string(baseList[i][rule.Key]) == key {
+				baseList[i] = entry
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			baseList = append(baseList, entry)
+		}
+	}
+	list, err := json.Marshal(baseList)
+	if err != nil {
+		return nil, err
+	}
+	base[rule.List] = list
+	return json.Marshal(base)
+}
+
+/* A struct for a merged json file kept in memory, it is its own fs.FileInfo */
+type memFile struct {
+	*bytes.Reader
+	name string
+	size int64
+}
+
+func (f *memFile) Stat() (fs.FileInfo, error) { return f, nil }
+func (f *memFile) Close() error               { return nil }
+func (f *memFile) Name() string               { return path.Base(f.name) }
+func (f *memFile) Size() int64                { return f.size }
+func (f *memFile) Mode() fs.FileMode          { return 0444 }
+func (f *memFile) ModTime() time.Time         { return time.Time{} }
+func (f *memFile) IsDir() bool                { return false }
+func (f *memFile) Sys() interface{}           { return nil }
@@ -0,0 +1,95 @@
+package render
+
+import "strings"
+
+/* A struct for one cell of a Buffer */
+type Cell struct {
+	Ch    rune
+	Style Style
+}
+
+/* A Renderer that draws into memory so a frame can be read back as a string */
+type Buffer struct {
+	lines, cols int
+	cells       []Cell
+	frame       string
+	Frames      int // How many frames have been presented
+}
+
+/* A function that makes a new Buffer with a size */
+func NewBuffer(lines, cols int) *Buffer {
+	b := &Buffer{lines: lines, cols: cols, cells: make([]Cell, lines*cols)}
+	b.Clear()
+	return b
+}
+
+/* A method that returns the lines and columns of the buffer */
+func (b *Buffer) Size() (int, int) {
+	return b.lines, b.cols
+}
+
+/* A method that fills the buffer with spaces */
+func (b *Buffer) Clear() {
+	for i := range b.cells {
+		b.cells[i] = Cell{Ch: ' '}
+	}
+}
+
+/* A method that draws text into the buffer */
+func (b *Buffer) DrawText(y, x int, text string, style Style) {
+	for i, line := range splitLines(text) {
+		for j, ch := range []rune(line) {
+			b.Set(y+i, x+j, ch, style)
+		}
+	}
+}
+
+/* A method that draws ascii art into the buffer without drawing the spaces */
+func (b *Buffer) DrawSprite(y, x int, art []string, style Style) {
+	for i, line := range art {
+		for _, s := range spans(line) {
+			b.DrawText(y+i, x+s.offset, s.text, style)
+		}
+	}
+}
+
+/* A method that saves the buffer as the last presented frame */
+func (b *Buffer) Present() {
+	b.frame = b.String()
+	b.Frames++
+}
+
+/* A method that sets one cell, cells outside of the buffer are ignored */
+func (b *Buffer) Set(y, x int, ch rune, style Style) {
+	if y < 0 || y >= b.lines || x < 0 || x >= b.cols {
+		return
+	}
+	b.cells[y*b.cols+x] = Cell{ch, style}
+}
+
+/* A method that returns one cell, cells outside of the buffer are blank */
+func (b *Buffer) At(y, x int) Cell {
+	if y < 0 || y >= b.lines || x < 0 || x >= b.cols {
+		return Cell{Ch: ' '}
+	}
+	return b.cells[y*b.cols+x]
+}
+
+/* A method that returns the last presented frame */
+func (b *Buffer) Frame() string {
+	return b.frame
+}
+
+/* A method that returns what is in the buffer right now with one line per row and the trailing spaces removed */
+func (b *Buffer) String() string {
+	var sb strings.Builder
+	for y := 0; y < b.lines; y++ {
+		row := make([]rune, b.cols)
+		for x := range row {
+			row[x] = b.cells[y*b.cols+x].Ch
+		}
+		sb.WriteString(strings.TrimRight(string(row), " "))
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
@@ -0,0 +1,66 @@
+package render
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/esa1234567/GoSpaceshipGame/game"
+)
+
+/* golden is the frame TestDrawFrameGolden draws, the spaceship's last line is under the weapon line */
+var golden = strings.TrimPrefix(`
+Life: [*****]    Score: 0    TimeLeft: 3
+
+
+^                    -^^^             ^^
+-)}                 {(-+-)}         {(-+
+-)}                 {(-+-)}         {(-+
+-)}   ,             {(-+-)}         {(-+
+*     |\-             ***             **
+    ->|^===0
+      |/-
+Weapon 1/1: Blaster [==========]
+
+`, "\n")
+
+func TestDrawFrameGolden(t *testing.T) {
+	w := game.NewWorld(game.Options{
+		Lines:     12,
+		Cols:      40,
+		Character: &game.Character{Name: "test"},
+		Level:     game.Level{Time: 30},
+		Seed:      1,
+	})
+	// The bullet shows through the spaces of the enemy drawn over it
+	w.Spawn(game.NewBullet(3, 21, 1))
+	w.Spawn(game.NewEnemyShip(3, 20, &game.DefaultEnemyType, w.TickRate))
+	// Enemies half off the left and the right edges are clipped
+	w.Spawn(game.NewEnemyShip(3, -4, &game.DefaultEnemyType, w.TickRate))
+	w.Spawn(game.NewEnemyShip(3, 36, &game.DefaultEnemyType, w.TickRate))
+
+	b := NewBuffer(12, 40)
+	DrawFrame(b, &Starfield{Lines: 12, Cols: 40}, w)
+	if got := b.Frame(); got != golden {
+		t.Errorf("frame is\n%s\nwant\n%s", got, golden)
+	}
+	if b.Frames != 1 {
+		t.Errorf("%d frames were presented, want 1", b.Frames)
+	}
+}
+
+func TestHUDFieldsDontOverlap(t *testing.T) {
+	w := game.NewWorld(game.Options{
+		Lines:     12,
+		Cols:      80,
+		Character: &game.Character{Name: "hauler", Attributes: game.Attributes{MaxHealth: 12, Shield: 3}},
+		Level:     game.Level{Time: 30, Enemies: 10},
+		Seed:      1,
+	})
+	b := NewBuffer(12, 80)
+	DrawHUD(b, w)
+	b.Present()
+	want := "Life: [************] (ooo)    Score: 0    TimeLeft: 30s    Enemies: 0/10"
+	if got := strings.SplitN(b.Frame(), "\n", 2)[0]; strings.TrimRight(got, " ") != want {
+		t.Errorf("the HUD is\n%q\nwant\n%q", got, want)
+	}
+}
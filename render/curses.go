@@ -0,0 +1,89 @@
+package render
+
+import gc "github.com/rthornton128/goncurses"
+
+/* A Renderer that draws on a goncurses window */
+type Curses struct {
+	win *gc.Window
+}
+
+/* A function that makes a Renderer for a goncurses window and sets up the colour pairs */
+func NewCurses(win *gc.Window) *Curses {
+	gc.InitPair(int16(White), gc.C_WHITE, gc.C_BLACK)
+	gc.InitPair(int16(Yellow), gc.C_YELLOW, gc.C_BLACK)
+	gc.InitPair(int16(Magenta), gc.C_MAGENTA, gc.C_BLACK)
+	gc.InitPair(int16(Red), gc.C_RED, gc.C_BLACK)
+
+	gc.InitPair(int16(Blue), gc.C_BLUE, gc.C_BLACK)
+	gc.InitPair(int16(Green), gc.C_GREEN, gc.C_BLACK)
+	return &Curses{win}
+}
+
+/* A method that returns the lines and columns of the window */
+func (c *Curses) Size() (int, int) {
+	return c.win.MaxYX()
+}
+
+/* A method that clears the window */
+func (c *Curses) Clear() {
+	c.win.Erase()
+}
+
+/* A method that draws text on the window */
+func (c *Curses) DrawText(y, x int, text string, style Style) {
+	attr := c.attr(style)
+	c.win.AttrOn(attr)
+	for i, line := range splitLines(text) {
+		c.print(y+i, x, line)
+	}
+	c.win.AttrOff(attr)
+}
+
+/* A method that draws ascii art on the window without drawing the spaces */
+func (c *Curses) DrawSprite(y, x int, art []string, style Style) {
+	attr := c.attr(style)
+	c.win.AttrOn(attr)
+	for i, line := range art {
+		for _, s := range spans(line) {
+			c.print(y+i, x+s.offset, s.text)
+		}
+	}
+	c.win.AttrOff(attr)
+}
+
+/* A method that prints one line of text on the window, the part outside of the window is cut off like Buffer.Set does instead of wrapping */
+func (c *Curses) print(y, x int, text string) {
+	lines, cols := c.win.MaxYX()
+	if y < 0 || y >= lines {
+		return
+	}
+	runes := []rune(text)
+	if x < 0 {
+		if -x >= len(runes) {
+			return
+		}
+		runes = runes[-x:]
+		x = 0
+	}
+	if x+len(runes) > cols {
+		if x >= cols {
+			return
+		}
+		runes = runes[:cols-x]
+	}
+	c.win.MovePrint(y, x, string(runes))
+}
+
+/* A method that refreshes the window */
+func (c *Curses) Present() {
+	c.win.Refresh()
+}
+
+/* A method that turns a style into curses attributes */
+func (c *Curses) attr(style Style) gc.Char {
+	attr := gc.ColorPair(int16(style.Color))
+	if style.Bold {
+		attr |= gc.A_BOLD
+	}
+	return attr
+}
@@ -0,0 +1,88 @@
+/* Package render draws the game world through a Renderer so the same frames can go to the terminal or to memory */
+package render
+
+import "strings"
+
+/* A colour that can be drawn with */
+type Color int
+
+/* The colours that can be drawn with, the numbers are also the curses colour pairs */
+const (
+	Default Color = iota
+	White
+	Yellow
+	Magenta
+	Red
+	Blue
+	Green
+)
+
+/* A function that takes the name of a colour like in characters.json and returns the colour */
+func ColorNamed(name string) Color {
+	switch name {
+	case "white":
+		return White
+	case "yellow":
+		return Yellow
+	case "magenta":
+		return Magenta
+	case "red":
+		return Red
+	case "blue":
+		return Blue
+	case "green":
+		return Green
+	default:
+		return Default
+	}
+}
+
+/* A function that checks if a colour name is known, the empty name is the default colour */
+func KnownColor(name string) bool {
+	return name == "" || ColorNamed(name) != Default
+}
+
+/* A struct for how something is drawn */
+type Style struct {
+	Color Color
+	Bold  bool
+}
+
+/* An interface for anything that can draw a frame of the game */
+type Renderer interface {
+	Size() (int, int)                               // The lines and columns that can be drawn on
+	Clear()                                         // Clears the frame being drawn
+	DrawText(y, x int, text string, style Style)    // Draws text where every line starts at x
+	DrawSprite(y, x int, art []string, style Style) // Draws ascii art where spaces are see-through
+	Present()                                       // Shows the frame that was drawn
+}
+
+/* A struct for a piece of a line of ascii art that isn't see-through */
+type span struct {
+	offset int
+	text   string
+}
+
+/* A function that splits a line of ascii art into the pieces that aren't spaces */
+func spans(line string) []span {
+	var out []span
+	runes := []rune(line)
+	for i := 0; i < len(runes); {
+		if runes[i] == ' ' {
+			i++
+			continue
+		}
+		j := i
+		for j < len(runes) && runes[j] != ' ' {
+			j++
+		}
+		out = append(out, span{i, string(runes[i:j])})
+		i = j
+	}
+	return out
+}
+
+/* A function that splits text into lines and removes the carriage returns */
+func splitLines(text string) []string {
+	return strings.Split(strings.ReplaceAll(text, "\r", ""), "\n")
+}
@@ -0,0 +1,55 @@
+package render
+
+import "math/rand"
+
+/* star_density is how dense the stars are the higher the density the lower the density */
+const star_density = 0.005
+
+/* planet_density is how dense the planets are the higher the density the lower the density */
+const planet_density = 0.0005
+
+/* A struct for one star or planet of the starfield */
+type star struct {
+	y, x  int
+	ch    string
+	style Style
+}
+
+/* A struct for a starfield that is wider than the screen so it can scroll */
+type Starfield struct {
+	Lines, Cols int
+	stars       []star
+}
+
+/* A function that generates a starfield using rng and returns it */
+func NewStarfield(pl, pc int, rng *rand.Rand) *Starfield {
+	f := &Starfield{Lines: pl, Cols: pc}
+	stars := int(float64(pc*pl) * star_density)
+	planets := int(float64(pc*pl) * planet_density)
+	for i := 0; i < stars; i++ {
+		y, x := rng.Intn(pl), rng.Intn(pc)
+		c := Color(rng.Intn(4) + 1)
+		f.stars = append(f.stars, star{y, x, ".", Style{Color: c, Bold: true}})
+	}
+	for i := 0; i < planets; i++ {
+		y, x := rng.Intn(pl), rng.Intn(pc)
+		c := Color(rng.Intn(2) + 5)
+		f.stars = append(f.stars, star{y, x, "o", Style{Color: c}})
+	}
+	return f
+}
+
+/* A method that draws the part of the starfield that starts at the column px, the starfield wraps around when it runs out */
+func (f *Starfield) Draw(r Renderer, px int) {
+	lines, cols := r.Size()
+	px %= f.Cols
+	for _, s := range f.stars {
+		x := s.x - px
+		if x < 0 {
+			x += f.Cols
+		}
+		if s.y < lines && x < cols {
+			r.DrawText(s.y, x, s.ch, s.style)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package render
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/esa1234567/GoSpaceshipGame/game"
+)
+
+/* A function that returns the style an object of the world is drawn with */
+func StyleFor(ob game.Object) Style {
+	style := Style{Color: ColorNamed(ob.Color())}
+	if _, ok := ob.(*game.Bullet); ok {
+		style.Bold = true
+	}
+	return style
+}
+
+/* A function that draws an object of the world */
+func DrawObject(r Renderer, ob game.Object) {
+	y, x := ob.YX()
+	r.DrawSprite(y, x, ob.Sprite(), StyleFor(ob))
+}
+
+/* A function that draws all of the objects of the world */
+func DrawObjects(r Renderer, w *game.World) {
+	for _, ob := range w.Objects {
+		DrawObject(r, ob)
+	}
+}
+
+/* HUDGap is how many cells there are between the fields at the top of the screen */
+const HUDGap = 4
+
+/* A function that draws the life, the score and the time left at the top of the screen, the life flashes red when the spaceship is hit */
+func DrawHUD(r Renderer, w *game.World) {
+	life := fmt.Sprintf("Life: [%-*s]", w.Ship.MaxLife, strings.Repeat("*", w.Ship.Life))
+	if w.Ship.MaxShield > 0 {
+		life += fmt.Sprintf(" (%-*s)", w.Ship.MaxShield, strings.Repeat("o", w.Ship.Shield))
+	}
+	style := Style{}
+	if w.Ship.Flashing() {
+		style = Style{Color: Red, Bold: true}
+	}
+	// Every field starts after the one before it so that long life bars don't cover the score
+	x := 0
+	field := func(text string, style Style) {
+		r.DrawText(0, x, text, style)
+		x += len(text) + HUDGap
+	}
+	field(life, style)
+	field(fmt.Sprintf("Score: %d", w.Ship.Score), Style{})
+	field(fmt.Sprintf("TimeLeft: %ds", w.TimeLeft), Style{})
+	if w.Level.Enemies > 0 {
+		field(fmt.Sprintf("Enemies: %d/%d", w.Kills, w.Level.Enemies), Style{})
+	}
+	if w.Level.Score > 0 {
+		field(fmt.Sprintf("Target: %d", w.Level.Score), Style{})
+	}
+	if w.Boss != nil {
+		DrawBossBar(r, w.Boss)
+	}
+	DrawEffects(r, w)
+	DrawWeapons(r, w.Ship)
+}
+
+/* CooldownBarWidth is how many cells wide the cooldown bar of a weapon is */
+const CooldownBarWidth = 10
+
+/* A function that draws the active weapon and the secondary weapon with their cooldowns and ammo above the last line */
+func DrawWeapons(r Renderer, s *game.Ship) {
+	lines, _ := r.Size()
+	text := fmt.Sprintf("Weapon %d/%d: %s", s.Active+1, len(s.Weapons), weaponStatus(s.Weapon()))
+	if s.Secondary != nil {
+		text += "   Secondary: " + weaponStatus(s.Secondary)
+	}
+	r.DrawText(lines-2, 0, text, Style{Color: Green})
+}
+
+/* A function that returns the name, the cooldown bar and the ammo of a weapon */
+func weaponStatus(ws *game.WeaponState) string {
+	filled := CooldownBarWidth
+	if ws.Cooldown > 0 && ws.Reload > 0 {
+		filled = (ws.Reload - ws.Cooldown) * CooldownBarWidth / ws.Reload
+	}
+	text := fmt.Sprintf("%s [%s%s]", ws.Weapon.Name, strings.Repeat("=", filled), strings.Repeat(" ", CooldownBarWidth-filled))
+	if ws.Weapon.Ammo > 0 {
+		text += fmt.Sprintf(" x%d", ws.Ammo)
+	}
+	return text
+}
+
+/* A function that draws the timed pickups of the spaceship and the seconds they have left on the last line */
+func DrawEffects(r Renderer, w *game.World) {
+	lines, _ := r.Size()
+	x := 0
+	for _, e := range w.Ship.ActiveEffects(w.TickRate) {
+		text := fmt.Sprintf("%s %ds", e.Kind.Label(), e.Seconds)
+		r.DrawText(lines-1, x, text, Style{Color: Yellow, Bold: true})
+		x += len(text) + 3
+	}
+}
+
+/* BossBarWidth is how many cells wide the health bar of the boss is */
+const BossBarWidth = 40
+
+/* A function that draws the health bar and the phase of the boss under the rest of the HUD */
+func DrawBossBar(r Renderer, b *game.Boss) {
+	health, max := b.Health()
+	filled := 0
+	if max > 0 {
+		filled = (health*BossBarWidth + max - 1) / max
+	}
+	bar := strings.Repeat("#", filled) + strings.Repeat("-", BossBarWidth-filled)
+	r.DrawText(1, 0, fmt.Sprintf("BOSS [%s] Phase %d", bar, b.Phase), Style{Color: Magenta, Bold: true})
+}
+
+/* A function that draws a whole frame of the world and presents it */
+func DrawFrame(r Renderer, field *Starfield, w *game.World) {
+	DrawScene(r, field, w)
+	r.Present()
+}
+
+/* A function that draws a whole frame of the world without presenting it so that menus can be drawn over it */
+func DrawScene(r Renderer, field *Starfield, w *game.World) {
+	r.Clear()
+	field.Draw(r, w.Scroll)
+	DrawObjects(r, w)
+	DrawHUD(r, w)
+}
+
+/* A function that draws lines of text in a box centred on the screen, the box hides what is under it */
+func DrawPanel(r Renderer, lines []string, style Style) {
+	width := 0
+	for _, line := range lines {
+		if len(line) > width {
+			width = len(line)
+		}
+	}
+	screenLines, screenCols := r.Size()
+	y := (screenLines - len(lines) - 2) / 2
+	x := (screenCols - width - 4) / 2
+	border := "+" + strings.Repeat("-", width+2) + "+"
+	r.DrawText(y, x, border, style)
+	for i, line := range lines {
+		r.DrawText(y+1+i, x, fmt.Sprintf("| %-*s |", width, line), style)
+	}
+	r.DrawText(y+1+len(lines), x, border, style)
+}
@@ -0,0 +1,261 @@
+/* Package replay records the input of a run of a level so that it can be played again exactly the same way */
+package replay
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/esa1234567/GoSpaceshipGame/game"
+	"github.com/esa1234567/GoSpaceshipGame/settings"
+)
+
+/* Magic is the first line of every replay file */
+const Magic = "space-glide replay"
+
+/* Version is the version of the replay file format */
+const Version = 1
+
+/* ContentFiles are the files of the content a run depends on, a replay only plays back the same way with the same files */
+var ContentFiles = []string{
+	"json/characters.json",
+	"json/enemies.json",
+	"json/levels.json",
+	"design/spaceship_boss_fight_minions.txt",
+}
+
+/* A json structure for everything a world is made from and how the run ended */
+type Header struct {
+	Version  int               `json:"version"`        /* The version of the replay file format */
+	Seed     int64             `json:"seed"`           /* The seed of the world */
+	TickRate int               `json:"tick_rate"`      /* How many times a second the world was stepped */
+	Lines    int               `json:"lines"`          /* The number of lines of the world */
+	Cols     int               `json:"cols"`           /* The number of columns of the world */
+	Level    int               `json:"level"`          /* The number of the level */
+	Ship     string            `json:"ship"`           /* The name of the spaceship, empty for the default one */
+	Pack     string            `json:"pack,omitempty"` /* The name of the content pack, empty for the base content */
+	Content  map[string]string `json:"content"`        /* The sha256 of every file in ContentFiles, empty for a missing file */
+	Ticks    int               `json:"ticks"`          /* How many steps the run lasted */
+	Outcome  game.Outcome      `json:"outcome"`        /* How the run ended, Running if the player quit */
+	Score    int               `json:"score"`          /* The score at the end of the run */
+}
+
+/* A struct for a replay, the header and the input of every step */
+type Replay struct {
+	Header
+	Inputs []game.Input
+}
+
+/* A function that starts the replay of a run of a world, the content hashes come from fsys */
+func New(world *game.World, ship, pack string, fsys fs.FS) (*Replay, error) {
+	hashes, err := HashContent(fsys)
+	if err != nil {
+		return nil, err
+	}
+	return &Replay{Header: Header{
+		Version:  Version,
+		Seed:     world.Seed,
+		TickRate: world.TickRate,
+		Lines:    world.Lines,
+		Cols:     world.Cols,
+		Level:    world.Level.Number,
+		Ship:     ship,
+		Pack:     pack,
+		Content:  hashes,
+	}}, nil
+}
+
+/* A method that adds the input of one step */
+func (r *Replay) Record(in game.Input) {
+	r.Inputs = append(r.Inputs, in)
+}
+
+/* A method that stores how the run of a world ended */
+func (r *Replay) Finish(world *game.World) {
+	r.Ticks = len(r.Inputs)
+	r.Outcome = world.Outcome
+	r.Score = world.Ship.Score
+}
+
+/* A method that checks that a world that played the replay ended the same way as the run that was recorded */
+func (r *Replay) Check(world *game.World) error {
+	if world.Ticks != r.Ticks || world.Outcome != r.Outcome || world.Ship.Score != r.Score {
+		return fmt.Errorf("the replay went out of sync: it recorded %q with %d points after %d steps but played back %q with %d points after %d steps",
+			r.Outcome, r.Score, r.Ticks, world.Outcome, world.Ship.Score, world.Ticks)
+	}
+	return nil
+}
+
+/* A function that returns the sha256 of every file in ContentFiles in fsys */
+func HashContent(fsys fs.FS) (map[string]string, error) {
+	hashes := make(map[string]string)
+	for _, name := range ContentFiles {
+		data, err := fs.ReadFile(fsys, name)
+		if errors.Is(err, fs.ErrNotExist) {
+			hashes[name] = ""
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		sum := sha256.Sum256(data)
+		hashes[name] = hex.EncodeToString(sum[:])
+	}
+	return hashes, nil
+}
+
+/* A method that returns an error naming every file in fsys that is not the same as when the replay was recorded */
+func (r *Replay) Verify(fsys fs.FS) error {
+	hashes, err := HashContent(fsys)
+	if err != nil {
+		return err
+	}
+	var changed []string
+	for name, sum := range r.Content {
+		if hashes[name] != sum {
+			changed = append(changed, name)
+		}
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+	sort.Strings(changed)
+	return fmt.Errorf("the content is not the same as when the replay was recorded, these files changed: %s", strings.Join(changed, ", "))
+}
+
+/* The bits of one input in a replay file */
+const (
+	bitUp = 1 << iota
+	bitDown
+	bitLeft
+	bitRight
+	bitShoot
+	bitSecondary
+	bitCycle
+)
+
+/* A function that packs an input into one byte */
+func encodeInput(in game.Input) byte {
+	var b byte
+	bits := []struct {
+		pressed bool
+		bit     byte
+	}{
+		{in.Up, bitUp}, {in.Down, bitDown}, {in.Left, bitLeft}, {in.Right, bitRight},
+		{in.Shoot, bitShoot}, {in.Secondary, bitSecondary}, {in.Cycle, bitCycle},
+	}
+	for _, k := range bits {
+		if k.pressed {
+			b |= k.bit
+		}
+	}
+	return b
+}
+
+/* A function that unpacks an input from one byte */
+func decodeInput(b byte) game.Input {
+	return game.Input{
+		Up:        b&bitUp != 0,
+		Down:      b&bitDown != 0,
+		Left:      b&bitLeft != 0,
+		Right:     b&bitRight != 0,
+		Shoot:     b&bitShoot != 0,
+		Secondary: b&bitSecondary != 0,
+		Cycle:     b&bitCycle != 0,
+	}
+}
+
+/* A method that writes the replay to w: the Magic line, the header as one line of json and then runs of the same input as the input packed into a byte and how many steps it lasted as a uvarint */
+func (r *Replay) Write(w io.Writer) error {
+	header, err := json.Marshal(r.Header)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%s\n%s\n", Magic, header)
+	count := make([]byte, binary.MaxVarintLen64)
+	for i := 0; i < len(r.Inputs); {
+		b := encodeInput(r.Inputs[i])
+		run := 1
+		for i+run < len(r.Inputs) && encodeInput(r.Inputs[i+run]) == b {
+			run++
+		}
+		buf.WriteByte(b)
+		buf.Write(count[:binary.PutUvarint(count, uint64(run))])
+		i += run
+	}
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+/* A method that writes the replay to a file */
+func (r *Replay) Save(filename string) error {
+	var buf bytes.Buffer
+	if err := r.Write(&buf); err != nil {
+		return err
+	}
+	return settings.WriteFileAtomic(filename, buf.Bytes(), 0644)
+}
+
+/* A function that reads a replay written by Write */
+func Read(rd io.Reader) (*Replay, error) {
+	br := bufio.NewReader(rd)
+	magic, err := br.ReadString('\n')
+	if err != nil || strings.TrimSuffix(magic, "\n") != Magic {
+		return nil, errors.New("not a space-glide replay")
+	}
+	line, err := br.ReadBytes('\n')
+	if err != nil {
+		return nil, fmt.Errorf("reading the header: %w", err)
+	}
+	r := &Replay{}
+	if err := json.Unmarshal(line, &r.Header); err != nil {
+		return nil, fmt.Errorf("reading the header: %w", err)
+	}
+	if r.Version > Version {
+		return nil, fmt.Errorf("the replay is version %d but this game only plays up to version %d", r.Version, Version)
+	}
+	for {
+		b, err := br.ReadByte()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		run, err := binary.ReadUvarint(br)
+		if err != nil {
+			return nil, fmt.Errorf("reading the inputs: %w", io.ErrUnexpectedEOF)
+		}
+		if run > uint64(r.Ticks) || len(r.Inputs)+int(run) > r.Ticks {
+			return nil, errors.New("reading the inputs: more inputs than steps")
+		}
+		for ; run > 0; run-- {
+			r.Inputs = append(r.Inputs, decodeInput(b))
+		}
+	}
+	if len(r.Inputs) != r.Ticks {
+		return nil, fmt.Errorf("reading the inputs: %d inputs for %d steps", len(r.Inputs), r.Ticks)
+	}
+	return r, nil
+}
+
+/* A function that reads a replay from a file */
+func Load(filename string) (*Replay, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return Read(file)
+}
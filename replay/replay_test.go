@@ -0,0 +1,186 @@
+package replay
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/esa1234567/GoSpaceshipGame/game"
+)
+
+/* A function that reads the content a replay depends on from the files of the game */
+func content(t *testing.T) fstest.MapFS {
+	t.Helper()
+	fsys := fstest.MapFS{}
+	for _, name := range ContentFiles {
+		data, err := os.ReadFile(filepath.Join("..", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		fsys[name] = &fstest.MapFile{Data: data}
+	}
+	return fsys
+}
+
+/* A function that makes the world of the replay tests, every world made from the same options plays the same way */
+func newWorld(t *testing.T, fsys fstest.MapFS, seed int64) *game.World {
+	t.Helper()
+	levels, err := game.LoadLevels(fsys, "json/levels.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	enemies, err := game.LoadEnemies(fsys, "json/enemies.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	level := levels.Levels[0]
+	level.Time = 20
+	return game.NewWorld(game.Options{Lines: 30, Cols: 100, Character: &game.Character{Name: "test"}, Level: level, Enemies: enemies, Seed: seed})
+}
+
+func TestWriteRead(t *testing.T) {
+	long := make([]game.Input, 1000)
+	for i := 500; i < len(long); i++ {
+		long[i] = game.Input{Shoot: true, Up: i%300 == 0}
+	}
+	tests := []struct {
+		name   string
+		inputs []game.Input
+	}{
+		{"empty", nil},
+		{"one", []game.Input{{Left: true}}},
+		{"every key", []game.Input{{Up: true, Down: true, Left: true, Right: true, Shoot: true, Secondary: true, Cycle: true}, {}}},
+		// Runs longer than 127 steps need more than one byte for their count
+		{"long runs", long},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Replay{Header: Header{Version: Version, Seed: 7, TickRate: 16, Level: 2, Ship: "Hauler", Content: map[string]string{"json/levels.json": "abc"}, Ticks: len(tt.inputs), Outcome: game.TimeUp, Score: 300}, Inputs: tt.inputs}
+			var buf bytes.Buffer
+			if err := r.Write(&buf); err != nil {
+				t.Fatal(err)
+			}
+			got, err := Read(&buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got.Header, r.Header) {
+				t.Errorf("read the header %+v, want %+v", got.Header, r.Header)
+			}
+			if len(got.Inputs) != len(r.Inputs) || (len(r.Inputs) > 0 && !reflect.DeepEqual(got.Inputs, r.Inputs)) {
+				t.Errorf("read %d inputs that are not the %d written", len(got.Inputs), len(r.Inputs))
+			}
+		})
+	}
+}
+
+func TestReadRejectsBadFiles(t *testing.T) {
+	var buf bytes.Buffer
+	r := &Replay{Header: Header{Version: Version, Ticks: 3}, Inputs: make([]game.Input, 3)}
+	if err := r.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	good := buf.String()
+	for name, data := range map[string]string{
+		"not a replay":   "hello\n",
+		"cut short":      good[:len(good)-1],
+		"too many steps": good + "\x00\x01",
+		"newer version":  strings.Replace(good, `"version":1`, `"version":99`, 1),
+	} {
+		if _, err := Read(strings.NewReader(data)); err == nil {
+			t.Errorf("reading a file that is %s gave no error", name)
+		}
+	}
+}
+
+func TestRecordAndPlayBack(t *testing.T) {
+	fsys := content(t)
+	world := newWorld(t, fsys, 42)
+	rec, err := New(world, "", "", fsys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rng := rand.New(rand.NewSource(3))
+	for !world.Over() {
+		in := game.Input{Up: rng.Intn(4) == 0, Down: rng.Intn(4) == 0, Shoot: rng.Intn(2) == 0}
+		world.Step(in)
+		rec.Record(in)
+	}
+	rec.Finish(world)
+
+	path := filepath.Join(t.TempDir(), "run.replay")
+	if err := rec.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := loaded.Verify(fsys); err != nil {
+		t.Fatal(err)
+	}
+	played := newWorld(t, fsys, loaded.Seed)
+	for _, in := range loaded.Inputs {
+		played.Step(in)
+	}
+	if err := loaded.Check(played); err != nil {
+		t.Error(err)
+	}
+
+	// The same inputs with enemies that are worth more points end another way
+	if loaded.Score == 0 {
+		t.Fatal("the recorded run scored no points so changing the score of the enemies changes nothing")
+	}
+	enemies, err := game.LoadEnemies(fsys, "json/enemies.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range enemies.Enemies {
+		enemies.Enemies[i].ScoreValue++
+	}
+	data, err := json.Marshal(enemies)
+	if err != nil {
+		t.Fatal(err)
+	}
+	changed := content(t)
+	changed["json/enemies.json"] = &fstest.MapFile{Data: data}
+	if err := loaded.Verify(changed); err == nil {
+		t.Error("the replay can be played with enemies that changed")
+	}
+	other := newWorld(t, changed, loaded.Seed)
+	for _, in := range loaded.Inputs {
+		other.Step(in)
+	}
+	if err := loaded.Check(other); err == nil {
+		t.Error("the replay is in sync with enemies that are worth more points")
+	}
+}
+
+func TestVerifyChangedContent(t *testing.T) {
+	fsys := content(t)
+	rec, err := New(newWorld(t, fsys, 1), "", "", fsys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	changed := content(t)
+	changed["json/enemies.json"] = &fstest.MapFile{Data: append([]byte(" "), changed["json/enemies.json"].Data...)}
+	delete(changed, "design/spaceship_boss_fight_minions.txt")
+	err = rec.Verify(changed)
+	if err == nil {
+		t.Fatal("the replay plays with content that changed")
+	}
+	for _, name := range []string{"json/enemies.json", "design/spaceship_boss_fight_minions.txt"} {
+		if !strings.Contains(err.Error(), name) {
+			t.Errorf("the error %q doesn't name %s", err, name)
+		}
+	}
+	if strings.Contains(err.Error(), "json/levels.json") {
+		t.Errorf("the error %q names json/levels.json which didn't change", err)
+	}
+}
@@ -0,0 +1,75 @@
+/* Package save keeps the progress of the player between runs */
+package save
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+
+	"github.com/esa1234567/GoSpaceshipGame/game"
+	"github.com/esa1234567/GoSpaceshipGame/settings"
+)
+
+/* A json structure for the campaign progress */
+type Campaign struct {
+	Unlocked int         `json:"unlocked"` /* The number of the highest unlocked level */
+	Best     map[int]int `json:"best"`     /* The best score for every level number that has been played */
+}
+
+/* A function that makes a new campaign where only the first level is unlocked */
+func NewCampaign(levels game.Levels) *Campaign {
+	c := &Campaign{Best: make(map[int]int)}
+	if len(levels.Levels) > 0 {
+		c.Unlocked = levels.Levels[0].Number
+	}
+	return c
+}
+
+/* A function that loads the campaign from a file, a missing file gives a new campaign */
+func LoadCampaign(filename string, levels game.Levels) (*Campaign, error) {
+	c := NewCampaign(levels)
+	data, err := os.ReadFile(filename)
+	if errors.Is(err, fs.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, c); err != nil {
+		return nil, err
+	}
+	if c.Best == nil {
+		c.Best = make(map[int]int)
+	}
+	return c, nil
+}
+
+/* A method that writes the campaign to a file */
+func (c *Campaign) Save(filename string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	return settings.WriteFileAtomic(filename, data, 0644)
+}
+
+/* A method that checks if the level at index i of levels can be played */
+func (c *Campaign) IsUnlocked(levels game.Levels, i int) bool {
+	return i == 0 || levels.Levels[i].Number <= c.Unlocked
+}
+
+/* A method that remembers the score of a run if it is the best one for that level */
+func (c *Campaign) Record(level game.Level, score int) {
+	if best, ok := c.Best[level.Number]; !ok || score > best {
+		c.Best[level.Number] = score
+	}
+}
+
+/* A method that records a won run of the level at index i and unlocks the level after it */
+func (c *Campaign) Complete(levels game.Levels, i int, score int) {
+	c.Record(levels.Levels[i], score)
+	if i+1 < len(levels.Levels) && levels.Levels[i+1].Number > c.Unlocked {
+		c.Unlocked = levels.Levels[i+1].Number
+	}
+}
@@ -0,0 +1,90 @@
+package save
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/esa1234567/GoSpaceshipGame/settings"
+)
+
+/* MaxHighScores is how many runs are kept for every level */
+const MaxHighScores = 10
+
+/* A json structure for one run in the high score table */
+type HighScore struct {
+	Name     string    `json:"name"`     /* The initials of the player */
+	Score    int       `json:"score"`    /* The score of the run */
+	Level    int       `json:"level"`    /* The number of the level */
+	Ship     string    `json:"ship"`     /* The name of the spaceship */
+	Duration int       `json:"duration"` /* How many seconds the run lasted */
+	Seed     int64     `json:"seed"`     /* The seed of the run so it can be played again */
+	Date     time.Time `json:"date"`     /* When the run was played */
+}
+
+/* A json structure for the high score tables of all of the levels */
+type HighScores struct {
+	Levels map[int][]HighScore `json:"levels"` /* The best runs for every level number from best to worst */
+}
+
+/* A function that loads the high scores from a file, a missing file gives empty tables */
+func LoadHighScores(filename string) (*HighScores, error) {
+	h := &HighScores{}
+	data, err := os.ReadFile(filename)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	if err == nil {
+		if err := json.Unmarshal(data, h); err != nil {
+			return nil, err
+		}
+	}
+	if h.Levels == nil {
+		h.Levels = make(map[int][]HighScore)
+	}
+	return h, nil
+}
+
+/* A method that writes the high scores to a file */
+func (h *HighScores) Save(filename string) error {
+	data, err := json.MarshalIndent(h, "", "  ")
+	if err != nil {
+		return err
+	}
+	return settings.WriteFileAtomic(filename, data, 0644)
+}
+
+/* A method that returns the best runs of a level from best to worst */
+func (h *HighScores) Top(level int) []HighScore {
+	return h.Levels[level]
+}
+
+/* A method that checks if a score would get into the table of a level */
+func (h *HighScores) Qualifies(level, score int) bool {
+	table := h.Levels[level]
+	return score > 0 && (len(table) < MaxHighScores || score > table[len(table)-1].Score)
+}
+
+/* A method that adds a run to the table of its level and returns its place starting at 1 or 0 if it didn't get in */
+func (h *HighScores) Add(entry HighScore) int {
+	if !h.Qualifies(entry.Level, entry.Score) {
+		return 0
+	}
+	table := append(h.Levels[entry.Level], entry)
+	sort.SliceStable(table, func(i, j int) bool {
+		return table[i].Score > table[j].Score
+	})
+	if len(table) > MaxHighScores {
+		table = table[:MaxHighScores]
+	}
+	h.Levels[entry.Level] = table
+	for i := range table {
+		if table[i] == entry {
+			return i + 1
+		}
+	}
+	return 0
+}
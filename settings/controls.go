@@ -0,0 +1,119 @@
+package settings
+
+import (
+	"errors"
+	"fmt"
+)
+
+/* A json structure for the controls */
+type Controls struct {
+	Up        string `json:"up"`        /* This is the control for moving up */
+	Down      string `json:"down"`      /* This is the control for moving down */
+	Left      string `json:"left"`      /* This is the control for moving left */
+	Right     string `json:"right"`     /* This is the control for moving right */
+	Shoot     string `json:"shoot"`     /* This is the control for shooting */
+	Cycle     string `json:"cycle"`     /* This is the control for switching to the next weapon */
+	Secondary string `json:"secondary"` /* This is the control for shooting the secondary weapon */
+	Pause     string `json:"pause"`     /* This is the control for pausing the game */
+}
+
+/* DefaultControls are the controls used for the ones missing from the settings file */
+var DefaultControls = Controls{
+	Up:        "w",
+	Down:      "s",
+	Left:      "a",
+	Right:     "d",
+	Shoot:     " ",
+	Cycle:     "e",
+	Secondary: "q",
+	Pause:     "p",
+}
+
+/* ControlCount is how many controls there are */
+const ControlCount = 8
+
+/* A method that fills in the controls that are missing */
+func (c *Controls) fillDefaults() {
+	for i := 0; i < ControlCount; i++ {
+		name, binding := c.ReturnControlForNumber(i)
+		if binding == "" {
+			_, def := DefaultControls.ReturnControlForNumber(i)
+			c.SetControlForString(def, name)
+		}
+	}
+}
+
+/* A method that checks that every control is one key and no key is used twice */
+func (c *Controls) Validate() error {
+	var errs []error
+	used := make(map[string]string)
+	for i := 0; i < ControlCount; i++ {
+		name, binding := c.ReturnControlForNumber(i)
+		if len([]rune(binding)) != 1 {
+			errs = append(errs, fmt.Errorf("control %s is %q, it must be one key", name, binding))
+			continue
+		}
+		if other, ok := used[binding]; ok {
+			errs = append(errs, fmt.Errorf("control %s uses the key %q of %s", name, binding, other))
+			continue
+		}
+		used[binding] = name
+	}
+	return errors.Join(errs...)
+}
+
+/* A method that replaces the invalid controls with the default ones and returns what was wrong with them */
+func (c *Controls) sanitize() error {
+	err := c.Validate()
+	if err == nil {
+		return nil
+	}
+	*c = DefaultControls
+	return fmt.Errorf("invalid controls, using the default ones: %w", err)
+}
+
+/* A method that changes the controls with 'dataForControl' and 'control' which are 'what you want to put for the control' and 'which control'  */
+func (c *Controls) SetControlForString(dataForControl string, control string) {
+	switch control {
+	case "up":
+		c.Up = dataForControl
+	case "down":
+		c.Down = dataForControl
+	case "left":
+		c.Left = dataForControl
+	case "right":
+		c.Right = dataForControl
+	case "shoot":
+		c.Shoot = dataForControl
+	case "cycle":
+		c.Cycle = dataForControl
+	case "secondary":
+		c.Secondary = dataForControl
+	case "pause":
+		c.Pause = dataForControl
+	}
+}
+
+/* A method that takes a number and returns the data for that number */
+func (c *Controls) ReturnControlForNumber(n int) (string, string) {
+	switch n {
+	case 0:
+		return "up", c.Up
+	case 1:
+		return "down", c.Down
+	case 2:
+		return "left", c.Left
+	case 3:
+		return "right", c.Right
+	case 4:
+		return "shoot", c.Shoot
+	case 5:
+		return "cycle", c.Cycle
+	case 6:
+		return "secondary", c.Secondary
+	case 7:
+		return "pause", c.Pause
+	default:
+		return "", ""
+	}
+}
@@ -0,0 +1,24 @@
+package settings
+
+/* migrations upgrade the settings by one version, migrations[i] upgrades version i+1 to version i+2 */
+var migrations = []func(*Settings){
+	migrateV1,
+}
+
+/* A function that upgrades the settings to the current version */
+func migrate(s *Settings) {
+	for s.Version < Version {
+		migrations[s.Version-1](s)
+		s.Version++
+	}
+}
+
+/* A function that upgrades version 1 settings, which only had controls and could have "space" as the shoot key */
+func migrateV1(s *Settings) {
+	c := &s.Controls
+	for _, binding := range []*string{&c.Up, &c.Down, &c.Left, &c.Right, &c.Shoot} {
+		if *binding == "space" {
+			*binding = " "
+		}
+	}
+}
@@ -0,0 +1,141 @@
+/* Package settings loads and saves the settings of space-glide, missing files and fields get the defaults and older files are migrated to the current version */
+package settings
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+/* Version is the version of the settings written by this version of the game */
+const Version = 2
+
+/* A json structure for the volume settings */
+type Audio struct {
+	Volume      float64 `json:"volume"`       /* The volume of everything from 0 to 1 */
+	MusicVolume float64 `json:"music_volume"` /* The volume of the music from 0 to 1, on top of the volume */
+	Muted       bool    `json:"muted"`        /* If nothing should be heard */
+}
+
+/* A json structure for the all of the settings */
+type Settings struct {
+	Version  int      `json:"version"`  /* The version of the settings, files without one are version 1 */
+	Controls Controls `json:"controls"` /* The keys of the game */
+	Audio    *Audio   `json:"audio"`    /* The volume settings, the default ones if there are none */
+
+	ContentPack string `json:"content_pack,omitempty"` /* The name of the content pack that is played, the base content if it is empty */
+}
+
+/* DefaultAudio are the volume settings used when the settings file has none */
+var DefaultAudio = Audio{Volume: 0.8, MusicVolume: 0.5}
+
+/* A function that returns the default settings */
+func Defaults() Settings {
+	audio := DefaultAudio
+	return Settings{Version: Version, Controls: DefaultControls, Audio: &audio}
+}
+
+/* A function that loads the settings from a json file, a missing or empty file gives the default settings. The settings can always be used, invalid controls are replaced by the defaults and the error says which ones */
+func Load(filename string) (Settings, error) {
+	file, err := os.Open(filename)
+	if errors.Is(err, os.ErrNotExist) {
+		return Defaults(), nil
+	}
+	if err != nil {
+		return Defaults(), err
+	}
+	defer file.Close()
+
+	// Read the JSON data from the file
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return Defaults(), err
+	}
+	return Parse(data)
+}
+
+/* A function that parses the settings from json data, see Load */
+func Parse(data []byte) (Settings, error) {
+	if len(strings.TrimSpace(string(data))) == 0 {
+		return Defaults(), nil
+	}
+	// Read the file over the defaults so that every missing field keeps its default, files without a version are version 1
+	s := Defaults()
+	s.Version = 0
+	if err := json.Unmarshal(data, &s); err != nil {
+		return Defaults(), err
+	}
+	if s.Version == 0 {
+		s.Version = 1
+	}
+	if s.Version < 1 {
+		return Defaults(), fmt.Errorf("settings version %d is not a version, the versions start at 1", s.Version)
+	}
+	if s.Version > Version {
+		return Defaults(), fmt.Errorf("settings version %d is newer than this game, it knows up to version %d", s.Version, Version)
+	}
+	migrate(&s)
+	s.fillDefaults()
+	return s, s.Controls.sanitize()
+}
+
+/* A method that fills in the settings that are empty or null */
+func (s *Settings) fillDefaults() {
+	s.Controls.fillDefaults()
+	if s.Audio == nil {
+		audio := DefaultAudio
+		s.Audio = &audio
+	}
+	s.Audio.Volume = clamp(s.Audio.Volume)
+	s.Audio.MusicVolume = clamp(s.Audio.MusicVolume)
+}
+
+/* A method that saves the settings to a json file, the file is written to a temporary file first so that it is never left half written */
+func (s Settings) Save(filename string) error {
+	s.Version = Version
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return WriteFileAtomic(filename, data, 0644)
+}
+
+/* A function that writes a file by writing a temporary file next to it and renaming it over the file */
+func WriteFileAtomic(filename string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(filename), "."+filepath.Base(filename)+".*")
+	if err != nil {
+		return err
+	}
+	// Remove the temporary file if anything goes wrong, after the rename this does nothing
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), perm); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filename)
+}
+
+/* A function that keeps a volume between 0 and 1 */
+func clamp(v float64) float64 {
+	if v < 0 {
+		return 0
+	}
+	if v > 1 {
+		return 1
+	}
+	return v
+}
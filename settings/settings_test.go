@@ -0,0 +1,61 @@
+package settings
+
+import "testing"
+
+func TestParseRejectsVersionsBelowOne(t *testing.T) {
+	for _, data := range []string{`{"version": -1}`, `{"version": -7}`} {
+		s, err := Parse([]byte(data))
+		if err == nil {
+			t.Errorf("Parse(%s) gave no error", data)
+		}
+		if s.Version != Version {
+			t.Errorf("Parse(%s) gave version %d, want the defaults", data, s.Version)
+		}
+	}
+}
+
+func TestParseMigratesVersionOne(t *testing.T) {
+	s, err := Parse([]byte(`{"controls": {"shoot": "space"}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Version != Version || s.Controls.Shoot != " " {
+		t.Errorf("got version %d and shoot %q, want version %d and shoot %q", s.Version, s.Controls.Shoot, Version, " ")
+	}
+}
+
+func TestParseFillsMissingFields(t *testing.T) {
+	s, err := Parse([]byte(`{"version": 2, "controls": {"up": "i"}, "audio": {"muted": true}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := DefaultAudio
+	want.Muted = true
+	if *s.Audio != want {
+		t.Errorf("got the audio %+v, want %+v", *s.Audio, want)
+	}
+	if s.Controls.Up != "i" || s.Controls.Down != DefaultControls.Down || s.Controls.Pause != DefaultControls.Pause {
+		t.Errorf("got up %q, down %q and pause %q, want %q and the defaults", s.Controls.Up, s.Controls.Down, s.Controls.Pause, "i")
+	}
+
+	s, err = Parse([]byte(`{"version": 2, "audio": {"volume": 0.3}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Audio.Volume != 0.3 || s.Audio.MusicVolume != DefaultAudio.MusicVolume {
+		t.Errorf("got the volume %v and the music volume %v, want 0.3 and %v", s.Audio.Volume, s.Audio.MusicVolume, DefaultAudio.MusicVolume)
+	}
+}
+
+func TestParseSanitizesControls(t *testing.T) {
+	s, err := Parse([]byte(`{"version": 2, "controls": {"up": "up", "down": "w"}}`))
+	if err == nil {
+		t.Fatal("Parse gave no error for a control that is not one key and a key used twice")
+	}
+	if s.Controls.Up != DefaultControls.Up || s.Controls.Down != DefaultControls.Down {
+		t.Errorf("got up %q and down %q, want the defaults %q and %q", s.Controls.Up, s.Controls.Down, DefaultControls.Up, DefaultControls.Down)
+	}
+	if err := s.Controls.Validate(); err != nil {
+		t.Errorf("the sanitized controls are invalid: %v", err)
+	}
+}
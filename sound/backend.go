@@ -0,0 +1,83 @@
+package sound
+
+import (
+	"fmt"
+	"sync"
+)
+
+/* An interface for where the mixer is played, the audio device, nowhere or a file */
+type Backend interface {
+	Name() string // The name of the backend as it is chosen with Open
+	Close() error // Stops playing the mixer
+}
+
+/* The backends that can be opened */
+const (
+	BackendAuto   = "auto"   // The audio device, or the null backend if it can't be opened
+	BackendDevice = "device" // The audio device
+	BackendNull   = "null"   // Nothing is heard
+	BackendWAV    = "wav"    // Everything is written to a wav file
+)
+
+/* A function that opens a backend by its name and starts playing the mixer on it, path is the file of the wav backend */
+func Open(name string, m *Mixer, path string) (Backend, error) {
+	switch name {
+	case BackendAuto, "":
+		if device, err := OpenDevice(m); err == nil {
+			return device, nil
+		}
+		return NewNull(m), nil
+	case BackendDevice:
+		return OpenDevice(m)
+	case BackendNull:
+		return NewNull(m), nil
+	case BackendWAV:
+		return OpenCapture(m, path)
+	default:
+		return nil, fmt.Errorf("unknown audio backend %q", name)
+	}
+}
+
+/* MaxRecordedSounds is how many of the last sounds played a Recorder keeps, so a long game doesn't keep growing it */
+const MaxRecordedSounds = 256
+
+/* A struct that keeps the last sounds played on a mixer in the order they were played */
+type Recorder struct {
+	mu     sync.Mutex
+	sounds []Sound
+}
+
+/* A method that adds a sound to the recorder, it is given to Mixer.Listen */
+func (r *Recorder) record(sound Sound) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.sounds) >= MaxRecordedSounds {
+		r.sounds = append(r.sounds[:0], r.sounds[1:]...)
+	}
+	r.sounds = append(r.sounds, sound)
+}
+
+/* A method that returns the last sounds played, at most MaxRecordedSounds of them */
+func (r *Recorder) Sounds() []Sound {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Sound(nil), r.sounds...)
+}
+
+/* A struct for the backend that plays nothing and only records the last sounds played */
+type Null struct {
+	Recorder
+}
+
+/* A function that makes a null backend for a mixer */
+func NewNull(m *Mixer) *Null {
+	n := &Null{}
+	m.Listen(n.record)
+	return n
+}
+
+/* A method that returns the name of the null backend */
+func (n *Null) Name() string { return BackendNull }
+
+/* A method that does nothing because the null backend has nothing to close */
+func (n *Null) Close() error { return nil }
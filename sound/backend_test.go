@@ -0,0 +1,80 @@
+package sound
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/esa1234567/GoSpaceshipGame/settings"
+)
+
+func TestNullRecordsSoundsInOrder(t *testing.T) {
+	m := NewMixer(settings.DefaultAudio)
+	m.Load(Shoot, Tone(440, 0.05, 0.5))
+	n := NewNull(m)
+	played := []Sound{Shoot, Hit, Shoot, Explosion}
+	for _, s := range played {
+		m.Play(s)
+	}
+	if got := n.Sounds(); !reflect.DeepEqual(got, played) {
+		t.Errorf("recorded %v, want %v", got, played)
+	}
+	if err := n.Close(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestNullKeepsTheLastSounds(t *testing.T) {
+	m := NewMixer(settings.DefaultAudio)
+	n := NewNull(m)
+	for i := 0; i < 2*MaxRecordedSounds; i++ {
+		m.Play(Shoot)
+	}
+	m.Play(Hit)
+	got := n.Sounds()
+	if len(got) != MaxRecordedSounds || got[len(got)-1] != Hit {
+		t.Errorf("recorded %d sounds ending with %v, want the last %d ending with %v", len(got), got[len(got)-1], MaxRecordedSounds, Hit)
+	}
+}
+
+func TestCaptureWritesWAVHeader(t *testing.T) {
+	m := NewMixer(settings.DefaultAudio)
+	path := filepath.Join(t.TempDir(), "capture.wav")
+	c, err := OpenCapture(m, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.Play(Shoot)
+	if err := c.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if got := c.Sounds(); !reflect.DeepEqual(got, []Sound{Shoot}) {
+		t.Errorf("recorded %v, want [%v]", got, Shoot)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) <= wavHeaderBytes {
+		t.Fatalf("the file is %d bytes, want samples after the %d byte header", len(data), wavHeaderBytes)
+	}
+	if string(data[0:4]) != "RIFF" || string(data[8:16]) != "WAVEfmt " || string(data[36:40]) != "data" {
+		t.Errorf("the header is %q, want a RIFF WAVE header", data[:wavHeaderBytes])
+	}
+	if riff := binary.LittleEndian.Uint32(data[4:]); int(riff) != len(data)-8 {
+		t.Errorf("the RIFF size is %d, want %d", riff, len(data)-8)
+	}
+	samples := binary.LittleEndian.Uint32(data[40:])
+	if int(samples) != len(data)-wavHeaderBytes {
+		t.Errorf("the data size is %d, want %d", samples, len(data)-wavHeaderBytes)
+	}
+	if samples%(Channels*2) != 0 {
+		t.Errorf("the data size %d is not a whole number of frames", samples)
+	}
+}
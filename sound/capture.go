@@ -0,0 +1,130 @@
+package sound
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"os"
+	"time"
+)
+
+/* CaptureInterval is how often the capture backend takes samples from the mixer */
+const CaptureInterval = 20 * time.Millisecond
+
+/* wavHeaderBytes is the size of the header of a wav file */
+const wavHeaderBytes = 44
+
+/* A struct for the backend that writes the mixer to a wav file in real time and records which sounds were played */
+type Capture struct {
+	Recorder
+	file    *os.File
+	bytes   int             // How many bytes of samples were written
+	done    chan struct{}   // Closed to stop writing
+	stopped chan struct{}   // Closed when the writing stopped
+	flush   chan chan error // Asks for samples to be written straight away
+	err     chan error
+}
+
+/* A function that creates a wav file and starts writing the mixer to it */
+func OpenCapture(m *Mixer, path string) (*Capture, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	c := &Capture{file: file, done: make(chan struct{}), stopped: make(chan struct{}), flush: make(chan chan error), err: make(chan error, 1)}
+	// The sizes in the header are filled in when the capture is closed
+	if err := c.writeHeader(); err != nil {
+		file.Close()
+		return nil, err
+	}
+	// WriteAt doesn't move the offset, the samples go after the header
+	if _, err := file.Seek(wavHeaderBytes, io.SeekStart); err != nil {
+		file.Close()
+		return nil, err
+	}
+	m.Listen(c.record)
+	go c.pump(m)
+	return c, nil
+}
+
+/* A method that keeps writing as many samples as the time that went by to the file until the capture is closed */
+func (c *Capture) pump(m *Mixer) {
+	ticker := time.NewTicker(CaptureInterval)
+	defer ticker.Stop()
+	defer close(c.stopped)
+	buf := make([]byte, int(CaptureInterval.Seconds()*SampleRate)*Channels*2)
+	write := func() error {
+		n, _ := m.Read(buf)
+		if _, err := c.file.Write(buf[:n]); err != nil {
+			return err
+		}
+		c.bytes += n
+		return nil
+	}
+	for {
+		select {
+		case <-c.done:
+			c.err <- nil
+			return
+		case reply := <-c.flush:
+			err := write()
+			reply <- err
+			if err != nil {
+				c.err <- err
+				return
+			}
+		case <-ticker.C:
+			if err := write(); err != nil {
+				c.err <- err
+				return
+			}
+		}
+	}
+}
+
+/* A method that writes the samples of one CaptureInterval to the file straight away without waiting for them */
+func (c *Capture) Flush() error {
+	reply := make(chan error, 1)
+	select {
+	case c.flush <- reply:
+		return <-reply
+	case <-c.stopped:
+		return errors.New("the capture is not writing anymore")
+	}
+}
+
+/* A method that writes the header of the wav file with the sizes of the samples written so far */
+func (c *Capture) writeHeader() error {
+	header := make([]byte, wavHeaderBytes)
+	copy(header[0:], "RIFF")
+	binary.LittleEndian.PutUint32(header[4:], uint32(wavHeaderBytes-8+c.bytes))
+	copy(header[8:], "WAVEfmt ")
+	binary.LittleEndian.PutUint32(header[16:], 16)                    // The size of the format chunk
+	binary.LittleEndian.PutUint16(header[20:], 1)                     // PCM
+	binary.LittleEndian.PutUint16(header[22:], Channels)              // Channels
+	binary.LittleEndian.PutUint32(header[24:], SampleRate)            // Sample rate
+	binary.LittleEndian.PutUint32(header[28:], SampleRate*Channels*2) // Bytes a second
+	binary.LittleEndian.PutUint16(header[32:], Channels*2)            // Bytes a frame
+	binary.LittleEndian.PutUint16(header[34:], 16)                    // Bits a sample
+	copy(header[36:], "data")
+	binary.LittleEndian.PutUint32(header[40:], uint32(c.bytes))
+	_, err := c.file.WriteAt(header, 0)
+	return err
+}
+
+/* A method that returns the name of the capture backend */
+func (c *Capture) Name() string { return BackendWAV }
+
+/* A method that stops writing, fills in the header and closes the wav file */
+func (c *Capture) Close() error {
+	close(c.done)
+	if err := <-c.err; err != nil {
+		c.file.Close()
+		return err
+	}
+	if err := c.writeHeader(); err != nil {
+		c.file.Close()
+		return err
+	}
+	return c.file.Close()
+}
@@ -0,0 +1,34 @@
+package sound
+
+import (
+	"io/fs"
+	"path"
+)
+
+/* MusicNotes is the bass line of the music, it loops for as long as the game runs */
+var MusicNotes = []float64{
+	110, 0, 165, 0, 220, 0, 165, 0,
+	98, 0, 147, 0, 196, 0, 147, 0,
+	87, 0, 131, 0, 175, 0, 131, 0,
+	98, 0, 147, 0, 196, 0, 247, 0,
+}
+
+/* A method that loads the sounds of the game, the menu sounds come from the mp3 files in dir of fsys and the rest is made by the synthesizer */
+func (m *Mixer) LoadDefaults(fsys fs.FS, dir string) error {
+	move, err := LoadClip(fsys, path.Join(dir, "SelectOption.mp3"))
+	if err != nil {
+		return err
+	}
+	enter, err := LoadClip(fsys, path.Join(dir, "Enter.mp3"))
+	if err != nil {
+		return err
+	}
+	m.Load(MenuMove, move)
+	m.Load(MenuEnter, enter)
+	m.Load(Shoot, Sweep(1200, 600, 0.06, 0.15))
+	m.Load(Explosion, Noise(0.35, 0.4, 1))
+	m.Load(Hit, Sweep(220, 80, 0.2, 0.35))
+	m.Load(GameOver, Melody([]float64{392, 330, 262, 196}, 0.25, 0.3))
+	m.PlayMusic(Melody(MusicNotes, 0.15, 0.08))
+	return nil
+}
@@ -0,0 +1,54 @@
+//go:build !noaudio
+
+package sound
+
+import "github.com/hajimehoshi/oto"
+
+/* deviceBufferBytes is how many bytes of samples the device buffers, about 50 milliseconds */
+const deviceBufferBytes = SampleRate / 20 * Channels * 2
+
+/* A struct for the audio device the mixer is played on, there is only one oto context for the whole game */
+type Device struct {
+	context *oto.Context
+	player  *oto.Player
+	done    chan struct{}
+}
+
+/* A function that opens the audio device and starts playing the mixer on it */
+func OpenDevice(m *Mixer) (*Device, error) {
+	context, err := oto.NewContext(SampleRate, Channels, 2, deviceBufferBytes)
+	if err != nil {
+		return nil, err
+	}
+	d := &Device{context: context, player: context.NewPlayer(), done: make(chan struct{})}
+	go d.pump(m)
+	return d, nil
+}
+
+/* A method that keeps writing the samples of the mixer to the device, writing blocks while the buffer of the device is full */
+func (d *Device) pump(m *Mixer) {
+	buf := make([]byte, deviceBufferBytes/2)
+	for {
+		select {
+		case <-d.done:
+			return
+		default:
+		}
+		n, _ := m.Read(buf)
+		if _, err := d.player.Write(buf[:n]); err != nil {
+			return
+		}
+	}
+}
+
+/* A method that returns the name of the device backend */
+func (d *Device) Name() string { return BackendDevice }
+
+/* A method that stops playing and closes the audio device */
+func (d *Device) Close() error {
+	close(d.done)
+	if err := d.player.Close(); err != nil {
+		return err
+	}
+	return d.context.Close()
+}
@@ -0,0 +1,21 @@
+//go:build noaudio
+
+package sound
+
+import "errors"
+
+/* A struct for the audio device, builds with the noaudio tag have none */
+type Device struct{}
+
+/* A function that always fails because the game was built with the noaudio tag */
+func OpenDevice(m *Mixer) (*Device, error) {
+	return nil, errors.New("built without audio support")
+}
+
+/* A method that returns the name of the device backend */
+func (d *Device) Name() string { return BackendDevice }
+
+/* A method that does nothing because there is no device */
+func (d *Device) Close() error {
+	return nil
+}
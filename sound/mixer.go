@@ -0,0 +1,139 @@
+package sound
+
+import (
+	"encoding/binary"
+	"math"
+	"sync"
+
+	"github.com/esa1234567/GoSpaceshipGame/settings"
+)
+
+/* MaxVoices is how many sound effects can play over each other, the oldest one stops when another one starts */
+const MaxVoices = 16
+
+/* A struct for a clip being played and how far along it is */
+type voice struct {
+	clip *Clip
+	pos  int
+	loop bool
+}
+
+/* A struct that mixes the sound effects and the looping music into one stream of samples */
+type Mixer struct {
+	mu        sync.Mutex
+	clips     map[Sound]*Clip
+	voices    []*voice
+	music     *voice
+	audio     settings.Audio
+	listeners []func(Sound)
+}
+
+/* A function that makes a mixer with no clips */
+func NewMixer(audio settings.Audio) *Mixer {
+	return &Mixer{clips: make(map[Sound]*Clip), audio: audio}
+}
+
+/* A method that sets the clip that is played for a sound */
+func (m *Mixer) Load(sound Sound, clip *Clip) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.clips[sound] = clip
+}
+
+/* A method that adds a function that is called with every sound that is played, even the ones without a clip */
+func (m *Mixer) Listen(listener func(Sound)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.listeners = append(m.listeners, listener)
+}
+
+/* A method that starts playing a sound over whatever is playing, sounds without a clip are only given to the listeners */
+func (m *Mixer) Play(sound Sound) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, listener := range m.listeners {
+		listener(sound)
+	}
+	clip := m.clips[sound]
+	if clip == nil {
+		return
+	}
+	if len(m.voices) >= MaxVoices {
+		m.voices = m.voices[1:]
+	}
+	m.voices = append(m.voices, &voice{clip: clip})
+}
+
+/* A method that starts looping a music track instead of the one playing */
+func (m *Mixer) PlayMusic(clip *Clip) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.music = &voice{clip: clip, loop: true}
+}
+
+/* A method that stops the music */
+func (m *Mixer) StopMusic() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.music = nil
+}
+
+/* A method that returns the volume settings */
+func (m *Mixer) Audio() settings.Audio {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.audio
+}
+
+/* A method that changes the volume settings, the volumes are kept between 0 and 1 */
+func (m *Mixer) SetAudio(audio settings.Audio) {
+	audio.Volume = math.Max(0, math.Min(1, audio.Volume))
+	audio.MusicVolume = math.Max(0, math.Min(1, audio.MusicVolume))
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.audio = audio
+}
+
+/* A method that fills p with the next 16 bit little endian stereo samples, it never runs out and gives silence when nothing is playing */
+func (m *Mixer) Read(p []byte) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	n := len(p) / (2 * Channels) * (2 * Channels)
+	volume := m.audio.Volume
+	if m.audio.Muted {
+		volume = 0
+	}
+	for i := 0; i < n; i += 2 {
+		sum := 0.0
+		for _, v := range m.voices {
+			sum += float64(v.next())
+		}
+		if m.music != nil {
+			sum += float64(m.music.next()) * m.audio.MusicVolume
+		}
+		sample := int16(math.Max(math.MinInt16, math.Min(math.MaxInt16, sum*volume)))
+		binary.LittleEndian.PutUint16(p[i:], uint16(sample))
+	}
+	// Forget the sound effects that finished
+	playing := m.voices[:0]
+	for _, v := range m.voices {
+		if v.pos < len(v.clip.Samples) {
+			playing = append(playing, v)
+		}
+	}
+	m.voices = playing
+	return n, nil
+}
+
+/* A method that returns the next sample of a voice, 0 once a voice that doesn't loop is finished */
+func (v *voice) next() int16 {
+	if v.pos >= len(v.clip.Samples) {
+		if !v.loop || len(v.clip.Samples) == 0 {
+			return 0
+		}
+		v.pos = 0
+	}
+	s := v.clip.Samples[v.pos]
+	v.pos++
+	return s
+}
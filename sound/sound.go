@@ -0,0 +1,83 @@
+/* Package sound plays the sound effects and the music of space-glide, everything goes through one mixer that an output device pulls samples from */
+package sound
+
+import (
+	"encoding/binary"
+	"io"
+	"io/fs"
+
+	"github.com/hajimehoshi/go-mp3"
+)
+
+/* SampleRate is how many samples a second the mixer makes, clips with another rate are resampled when they are loaded */
+const SampleRate = 44100
+
+/* Channels is how many channels the mixer makes, the samples of every clip are interleaved left and right */
+const Channels = 2
+
+/* A name of a sound effect that the game or the menus can play */
+type Sound string
+
+/* The sound effects of the game */
+const (
+	MenuMove  Sound = "menu_move"  // Moving between the options of a menu
+	MenuEnter Sound = "menu_enter" // Choosing an option of a menu
+	Shoot     Sound = "shoot"      // The spaceship shooting
+	Explosion Sound = "explosion"  // Something blowing up
+	Hit       Sound = "hit"        // The spaceship getting hit
+	GameOver  Sound = "game_over"  // The run ending without winning
+)
+
+/* A struct for a decoded sound with 16 bit stereo samples at SampleRate */
+type Clip struct {
+	Samples []int16
+}
+
+/* A method that returns how many seconds the clip lasts */
+func (c *Clip) Seconds() float64 {
+	return float64(len(c.Samples)/Channels) / SampleRate
+}
+
+/* A function that decodes an mp3 into a clip */
+func DecodeMP3(r io.Reader) (*Clip, error) {
+	decoder, err := mp3.NewDecoder(r)
+	if err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(decoder)
+	if err != nil {
+		return nil, err
+	}
+	samples := make([]int16, len(data)/2)
+	for i := range samples {
+		samples[i] = int16(binary.LittleEndian.Uint16(data[i*2:]))
+	}
+	return &Clip{Samples: resample(samples, decoder.SampleRate())}, nil
+}
+
+/* A function that loads an mp3 file in fsys into a clip */
+func LoadClip(fsys fs.FS, filename string) (*Clip, error) {
+	file, err := fsys.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return DecodeMP3(file)
+}
+
+/* A function that changes the rate of stereo samples to SampleRate by picking the nearest sample */
+func resample(samples []int16, rate int) []int16 {
+	if rate == SampleRate || rate <= 0 {
+		return samples
+	}
+	frames := len(samples) / Channels
+	n := int(int64(frames) * SampleRate / int64(rate))
+	out := make([]int16, n*Channels)
+	for i := 0; i < n; i++ {
+		src := int(int64(i) * int64(rate) / SampleRate)
+		for c := 0; c < Channels; c++ {
+			out[i*Channels+c] = samples[src*Channels+c]
+		}
+	}
+	return out
+}
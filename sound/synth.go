@@ -0,0 +1,60 @@
+package sound
+
+import (
+	"math"
+	"math/rand"
+)
+
+/* A function that makes a square wave clip, the volume fades out over the clip so that it doesn't click */
+func Tone(freq, seconds, volume float64) *Clip {
+	return Sweep(freq, freq, seconds, volume)
+}
+
+/* A function that makes a square wave clip that slides from one frequency to another */
+func Sweep(from, to, seconds, volume float64) *Clip {
+	n := int(seconds * SampleRate)
+	clip := &Clip{Samples: make([]int16, n*Channels)}
+	phase := 0.0
+	for i := 0; i < n; i++ {
+		t := float64(i) / float64(n)
+		phase += (from + (to-from)*t) / SampleRate
+		v := volume * (1 - t)
+		if math.Mod(phase, 1) >= 0.5 {
+			v = -v
+		}
+		clip.set(i, v)
+	}
+	return clip
+}
+
+/* A function that makes a clip of fading white noise, the seed makes it sound the same every time */
+func Noise(seconds, volume float64, seed int64) *Clip {
+	rng := rand.New(rand.NewSource(seed))
+	n := int(seconds * SampleRate)
+	clip := &Clip{Samples: make([]int16, n*Channels)}
+	for i := 0; i < n; i++ {
+		t := float64(i) / float64(n)
+		clip.set(i, volume*(1-t)*(1-t)*(rng.Float64()*2-1))
+	}
+	return clip
+}
+
+/* A function that makes a clip of notes played one after another, a frequency of 0 is a rest */
+func Melody(notes []float64, noteSeconds, volume float64) *Clip {
+	clip := &Clip{}
+	for _, freq := range notes {
+		if freq == 0 {
+			clip.Samples = append(clip.Samples, make([]int16, int(noteSeconds*SampleRate)*Channels)...)
+			continue
+		}
+		clip.Samples = append(clip.Samples, Tone(freq, noteSeconds, volume).Samples...)
+	}
+	return clip
+}
+
+/* A method that sets both channels of a frame of the clip from a value between -1 and 1 */
+func (c *Clip) set(frame int, v float64) {
+	s := int16(v * math.MaxInt16)
+	c.Samples[frame*Channels] = s
+	c.Samples[frame*Channels+1] = s
+}
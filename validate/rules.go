@@ -0,0 +1,358 @@
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+
+	"github.com/esa1234567/GoSpaceshipGame/game"
+	"github.com/esa1234567/GoSpaceshipGame/render"
+	"github.com/esa1234567/GoSpaceshipGame/settings"
+)
+
+/* ShipLines and ShipCols are the size of the window the spaceship was drawn in, the ascii art of a character must fit in it */
+const (
+	ShipLines = 5
+	ShipCols  = 7
+)
+
+/* The values the string fields of the content can have */
+var (
+	formations     = []string{"line", "v", "column", "sine"}
+	movements      = []string{"straight", "sine", "zigzag"}
+	bulletPatterns = []string{"single", "double", "spread"}
+	weaponKinds    = []string{"blaster", "laser", "homing", "bomb"}
+)
+
+/* bossDesign is the design file of the boss, its minions and their animations */
+const bossDesign = "design/spaceship_boss_fight_minions.txt"
+
+/* The labels of the animations in the boss design file and the events their frames can have */
+var (
+	bossAnimations = []string{game.AnimationMinion, game.AnimationMinionAttack, game.AnimationExplosion, game.AnimationThruster, game.AnimationBoss}
+	frameEvents    = []string{game.EventBullet, game.EventExplosion}
+)
+
+/* designFiles are the design files the game reads */
+var designFiles = []string{
+	"design/main_menu.txt",
+	"design/levels_menu.txt",
+	"design/death_menu.txt",
+	"design/level_complete_menu.txt",
+	bossDesign,
+}
+
+/* A struct for how the content is checked */
+type Options struct {
+	Partial bool     // The files that are missing are skipped, like the ones a content pack doesn't change
+	Base    fs.FS    // The content the checked content is laid over, the enemy types in it can be used by the levels
+	Replace []string // The json files that replace the ones of the base instead of being merged into them
+}
+
+/* A function that checks all of the content in fsys and returns the problems sorted by file and line */
+func Check(fsys fs.FS, opts Options) []Problem {
+	var problems []Problem
+	present := func(name string) bool {
+		if _, err := fs.Stat(fsys, name); err != nil {
+			if !opts.Partial || !errors.Is(err, fs.ErrNotExist) {
+				problems = append(problems, Problem{File: name, Message: err.Error()})
+			}
+			return false
+		}
+		return true
+	}
+
+	// The enemies first so that the levels can be checked against them
+	known := make(map[string]bool)
+	if opts.Base != nil {
+		if base, err := game.LoadEnemies(opts.Base, "json/enemies.json"); err == nil {
+			for _, e := range base.Enemies {
+				known[e.Name] = true
+			}
+		}
+	}
+	if present("json/enemies.json") {
+		var enemies game.EnemyTypes
+		if f, ok := readJSON(fsys, "json/enemies.json", &enemies, &problems); ok {
+			checkEnemies(f, enemies, known, &problems)
+		}
+	}
+	if present("json/characters.json") {
+		var characters game.Characters
+		if f, ok := readJSON(fsys, "json/characters.json", &characters, &problems); ok {
+			checkCharacters(f, characters, &problems)
+		}
+	}
+	if present("json/levels.json") {
+		var levels game.Levels
+		// The levels of a pack replace the base ones with the same number and come after the others
+		var base game.Levels
+		if opts.Base != nil && !contains(opts.Replace, "json/levels.json") {
+			base, _ = game.LoadLevels(opts.Base, "json/levels.json")
+		}
+		if f, ok := readJSON(fsys, "json/levels.json", &levels, &problems); ok {
+			checkLevels(f, levels, base, known, &problems)
+		}
+	}
+	// The settings are optional, the defaults are used without them
+	if data, err := fs.ReadFile(fsys, "json/settings.json"); err == nil {
+		if _, err := settings.Parse(data); err != nil {
+			problems = append(problems, Problem{File: "json/settings.json", Message: err.Error()})
+		}
+	}
+	for _, name := range designFiles {
+		if !present(name) {
+			continue
+		}
+		if content, err := fs.ReadFile(fsys, name); err != nil {
+			problems = append(problems, Problem{File: name, Message: err.Error()})
+		} else if len(game.DesignBlocks(string(content))) == 0 {
+			problems = append(problems, Problem{File: name, Message: "the design is empty"})
+		} else if name == bossDesign {
+			checkBossDesign(fsys, name, string(content), &problems)
+		}
+	}
+	sortProblems(problems)
+	return problems
+}
+
+/* A function that checks the enemy types and adds their names to known */
+func checkEnemies(f *jsonFile, enemies game.EnemyTypes, known map[string]bool, problems *[]Problem) {
+	names := make(map[string]bool)
+	for i, e := range enemies.Enemies {
+		path := fmt.Sprintf("enemies[%d]", i)
+		switch {
+		case e.Name == "":
+			f.problem(problems, path, "enemy %d has no name", i+1)
+		case names[e.Name]:
+			f.problem(problems, path+".name", "enemy %q is defined twice", e.Name)
+		}
+		names[e.Name] = true
+		known[e.Name] = true
+		if len(e.AsciiArt) == 0 && len(e.Frames) == 0 {
+			f.problem(problems, path, "enemy %q has no ascii_art", e.Name)
+		}
+		checkArt(f, problems, path+".ascii_art", e.Name, e.AsciiArt, 0, 0)
+		if e.Health <= 0 {
+			f.problem(problems, path+".health", "enemy %q must have a health above 0", e.Name)
+		}
+		if e.Speed <= 0 {
+			f.problem(problems, path+".speed", "enemy %q must have a speed above 0", e.Name)
+		}
+		if e.FireRate < 0 {
+			f.problem(problems, path+".fire_rate", "enemy %q can't have a negative fire_rate", e.Name)
+		}
+		if e.ScoreValue < 0 {
+			f.problem(problems, path+".score_value", "enemy %q can't have a negative score_value", e.Name)
+		}
+		checkColor(f, problems, path+".color", e.Color)
+		checkOneOf(f, problems, path+".bullet_pattern", "bullet_pattern", e.BulletPattern, bulletPatterns)
+		checkOneOf(f, problems, path+".movement", "movement", e.Movement, movements)
+		checkDrops(f, problems, path+".drops", e.Drops)
+	}
+}
+
+/* A function that checks the characters */
+func checkCharacters(f *jsonFile, characters game.Characters, problems *[]Problem) {
+	names := make(map[string]bool)
+	if len(characters.Characters) == 0 {
+		f.problem(problems, "characters", "there are no characters")
+	}
+	for i, c := range characters.Characters {
+		path := fmt.Sprintf("characters[%d]", i)
+		switch {
+		case c.Name == "":
+			f.problem(problems, path, "character %d has no name", i+1)
+		case names[c.Name]:
+			f.problem(problems, path+".name", "character %q is defined twice", c.Name)
+		}
+		names[c.Name] = true
+		if len(c.AsciiArt) == 0 {
+			f.problem(problems, path, "character %q has no ascii_art", c.Name)
+		}
+		checkArt(f, problems, path+".ascii_art", c.Name, c.AsciiArt, ShipLines, ShipCols)
+
+		a := c.Attributes
+		apath := path + ".attributes"
+		if a.Speed <= 0 {
+			f.problem(problems, apath+".speed", "character %q must have a speed above 0", c.Name)
+		}
+		if a.MaxHealth <= 0 && a.Damage <= 0 {
+			f.problem(problems, apath+".max_health", "character %q must have a max_health above 0", c.Name)
+		}
+		if a.Shield < 0 || a.ShieldRegen < 0 || a.Firepower < 0 || a.FireRate < 0 {
+			f.problem(problems, apath, "character %q can't have negative attributes", c.Name)
+		}
+		if a.Hitbox != nil && (a.Hitbox.Width <= 0 || a.Hitbox.Height <= 0) {
+			f.problem(problems, apath+".hitbox", "character %q must have a hitbox bigger than 0", c.Name)
+		}
+		checkColor(f, problems, apath+".color", a.Color)
+		for j, w := range c.Weapons {
+			checkWeapon(f, problems, fmt.Sprintf("%s.weapons[%d]", path, j), w)
+		}
+		if c.Secondary != nil {
+			checkWeapon(f, problems, path+".secondary", *c.Secondary)
+		}
+	}
+}
+
+/* A function that checks a weapon of a character */
+func checkWeapon(f *jsonFile, problems *[]Problem, path string, w game.Weapon) {
+	if w.Name == "" {
+		f.problem(problems, path, "the weapon has no name")
+	}
+	if w.FireRate < 0 || w.Damage < 0 || w.Speed < 0 || w.Spread < 0 || w.Ammo < 0 {
+		f.problem(problems, path, "weapon %q can't have negative values", w.Name)
+	}
+	checkOneOf(f, problems, path+".kind", "kind", w.Kind, weaponKinds)
+	checkColor(f, problems, path+".color", w.Color)
+}
+
+/* A function that checks the levels laid over the base levels against the known enemy types */
+func checkLevels(f *jsonFile, levels, base game.Levels, known map[string]bool, problems *[]Problem) {
+	if len(levels.Levels) == 0 && len(base.Levels) == 0 {
+		f.problem(problems, "levels", "there are no levels")
+	}
+	numbers := make(map[int]bool)
+	for _, l := range base.Levels {
+		numbers[l.Number] = true
+	}
+	count := len(numbers)
+	for i, l := range levels.Levels {
+		path := fmt.Sprintf("levels[%d]", i)
+		// The game finds the levels by their place in the list so the new numbers must count up from 1
+		if !numbers[l.Number] {
+			count++
+			if l.Number != count {
+				f.problem(problems, path+".number", "level %d must be number %d, the levels are numbered in order from 1", l.Number, count)
+			}
+			numbers[l.Number] = true
+		}
+		if l.Time <= 0 {
+			f.problem(problems, path+".time", "level %d must have a time above 0", l.Number)
+		}
+		if l.Enemies < 0 || l.Score < 0 {
+			f.problem(problems, path, "level %d can't have a negative target", l.Number)
+		}
+		if l.Enemies <= 0 && l.Score <= 0 {
+			f.problem(problems, path, "level %d can't be won, it needs enemies or a score above 0", l.Number)
+		}
+		for j, name := range l.EnemyTypes {
+			if !known[name] {
+				f.problem(problems, fmt.Sprintf("%s.enemy_types[%d]", path, j), "level %d spawns the unknown enemy type %q", l.Number, name)
+			}
+		}
+		for j, w := range l.Waves {
+			wpath := fmt.Sprintf("%s.waves[%d]", path, j)
+			if !known[w.Enemy] {
+				f.problem(problems, wpath+".enemy", "a wave of level %d spawns the unknown enemy type %q", l.Number, w.Enemy)
+			}
+			if w.Count <= 0 {
+				f.problem(problems, wpath+".count", "a wave of level %d must have a count above 0", l.Number)
+			}
+			if w.Time < 0 {
+				f.problem(problems, wpath+".time", "a wave of level %d can't have a negative time", l.Number)
+			}
+			checkOneOf(f, problems, wpath+".formation", "formation", w.Formation, formations)
+		}
+		checkDrops(f, problems, path+".drops", l.Drops)
+	}
+}
+
+/* A function that loads the boss design the way the game does and checks every animation in it */
+func checkBossDesign(fsys fs.FS, name, content string, problems *[]Problem) {
+	problem := func(line int, format string, args ...interface{}) {
+		*problems = append(*problems, Problem{File: name, Line: line, Message: fmt.Sprintf(format, args...)})
+	}
+	design, err := game.LoadBossDesign(fsys, name)
+	if err != nil {
+		problem(0, "%s", strings.TrimPrefix(err.Error(), name+": "))
+	}
+	for _, a := range game.ParseAnimations(content, 0, true) {
+		known := false
+		for _, label := range bossAnimations {
+			known = known || strings.EqualFold(a.Name, label)
+		}
+		if !known {
+			problem(a.Line, "unknown animation %q, it must be one of %q", a.Name, bossAnimations)
+		}
+		for i, f := range a.Frames {
+			if len(f.Art) == 0 {
+				problem(f.Line, "frame %d of the %q animation has no ascii art", i+1, a.Name)
+			}
+			if f.Event != "" && !contains(frameEvents, f.Event) {
+				problem(f.Line, "unknown event %q, it must be one of %v", f.Event, frameEvents)
+			}
+		}
+		// The parts of the boss are found in its ascii art, so every frame must be the same size
+		if strings.EqualFold(a.Name, game.AnimationBoss) && len(design.Art) > 0 {
+			want := game.HitboxFor(0, 0, design.Art)
+			for i, f := range a.Frames {
+				if box := game.HitboxFor(0, 0, f.Art); box.H != want.H || box.W != want.W {
+					problem(f.Line, "frame %d of the boss is %dx%d, it must be the size of the boss %dx%d", i+1, box.H, box.W, want.H, want.W)
+				}
+			}
+		}
+	}
+}
+
+/* A function that checks if a list of values has a value */
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+/* A function that checks a drop table */
+func checkDrops(f *jsonFile, problems *[]Problem, path string, drops []game.Drop) {
+	total := 0.0
+	for i, d := range drops {
+		dpath := fmt.Sprintf("%s[%d]", path, i)
+		if !d.Pickup.Valid() {
+			f.problem(problems, dpath+".pickup", "unknown pickup %q", d.Pickup)
+		}
+		if d.Chance < 0 || d.Chance > 1 {
+			f.problem(problems, dpath+".chance", "the chance of %q must be between 0 and 1", d.Pickup)
+		}
+		total += d.Chance
+	}
+	if total > 1 {
+		f.problem(problems, path, "the chances of the drops add up to more than 1")
+	}
+}
+
+/* A function that checks that ascii art has lines and fits in a size, a size of 0 is not checked */
+func checkArt(f *jsonFile, problems *[]Problem, path, name string, art []string, lines, cols int) {
+	if len(art) == 0 {
+		return
+	}
+	box := game.HitboxFor(0, 0, art)
+	if box.W == 0 {
+		f.problem(problems, path, "the ascii_art of %q is blank", name)
+	}
+	if lines > 0 && (box.H > lines || box.W > cols) {
+		f.problem(problems, path, "the ascii_art of %q is %dx%d, it must fit in %dx%d", name, box.H, box.W, lines, cols)
+	}
+}
+
+/* A function that checks that a colour is known */
+func checkColor(f *jsonFile, problems *[]Problem, path, color string) {
+	if !render.KnownColor(color) {
+		f.problem(problems, path, "unknown color %q", color)
+	}
+}
+
+/* A function that checks that a field is one of the values it can have, empty picks the default */
+func checkOneOf(f *jsonFile, problems *[]Problem, path, field, value string, values []string) {
+	if value == "" {
+		return
+	}
+	if contains(values, value) {
+		return
+	}
+	f.problem(problems, path, "unknown %s %q, it must be one of %v", field, value, values)
+}
@@ -0,0 +1,156 @@
+/* Package validate checks the content of space-glide and reports every problem with the file and the line it is on */
+package validate
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+/* A struct for one problem found in the content */
+type Problem struct {
+	File    string // The file the problem is in
+	Line    int    // The line the problem is on, 0 if it is about the whole file
+	Message string
+}
+
+/* A method that returns the problem like "json/levels.json:12: level 3 has no time" */
+func (p Problem) String() string {
+	if p.Line > 0 {
+		return fmt.Sprintf("%s:%d: %s", p.File, p.Line, p.Message)
+	}
+	return fmt.Sprintf("%s: %s", p.File, p.Message)
+}
+
+/* A struct for a json file being checked, it knows the line of every field so that problems can point at them */
+type jsonFile struct {
+	name  string
+	data  []byte
+	lines map[string]int // The line of every field and list entry by its path like "levels[2].waves[0].enemy"
+}
+
+/* A function that reads a json file, decodes it strictly into v and maps its fields to lines, it returns false if the file can't be used */
+func readJSON(fsys fs.FS, name string, v interface{}, problems *[]Problem) (*jsonFile, bool) {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		*problems = append(*problems, Problem{File: name, Message: err.Error()})
+		return nil, false
+	}
+	f := &jsonFile{name: name, data: data}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		*problems = append(*problems, f.decodeProblem(err))
+		return nil, false
+	}
+	f.lines = make(map[string]int)
+	f.walk(json.NewDecoder(bytes.NewReader(data)), "")
+	return f, true
+}
+
+/* A method that turns an error of the json decoder into a problem on the line it happened */
+func (f *jsonFile) decodeProblem(err error) Problem {
+	var syntax *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntax):
+		return Problem{File: f.name, Line: f.lineAt(syntax.Offset), Message: syntax.Error()}
+	case errors.As(err, &typeErr):
+		return Problem{File: f.name, Line: f.lineAt(typeErr.Offset), Message: fmt.Sprintf("%s must be of type %s, not %s", typeErr.Field, typeErr.Type, typeErr.Value)}
+	}
+	// Unknown fields are the only other error, find the first line with the field
+	msg := err.Error()
+	if i := strings.Index(msg, "unknown field "); i >= 0 {
+		field := msg[i+len("unknown field "):]
+		if j := bytes.Index(f.data, []byte(field+":")); j >= 0 {
+			return Problem{File: f.name, Line: f.lineAt(int64(j)), Message: strings.TrimPrefix(msg, "json: ")}
+		}
+		if j := bytes.Index(f.data, []byte(field)); j >= 0 {
+			return Problem{File: f.name, Line: f.lineAt(int64(j)), Message: strings.TrimPrefix(msg, "json: ")}
+		}
+	}
+	return Problem{File: f.name, Message: msg}
+}
+
+/* A method that reads the next json value from dec and records the line of every field and list entry in it */
+func (f *jsonFile) walk(dec *json.Decoder, path string) {
+	tok, err := dec.Token()
+	if err != nil {
+		return
+	}
+	switch tok {
+	case json.Delim('{'):
+		for dec.More() {
+			start := f.nextValue(dec.InputOffset())
+			key, err := dec.Token()
+			if err != nil {
+				return
+			}
+			sub := fmt.Sprint(key)
+			if path != "" {
+				sub = path + "." + sub
+			}
+			f.lines[sub] = f.lineAt(start)
+			f.walk(dec, sub)
+		}
+		dec.Token() // The closing brace
+	case json.Delim('['):
+		for i := 0; dec.More(); i++ {
+			sub := path + "[" + strconv.Itoa(i) + "]"
+			f.lines[sub] = f.lineAt(f.nextValue(dec.InputOffset()))
+			f.walk(dec, sub)
+		}
+		dec.Token() // The closing bracket
+	}
+}
+
+/* A method that skips the spaces, commas and colons after an offset and returns where the next value starts */
+func (f *jsonFile) nextValue(offset int64) int64 {
+	for offset < int64(len(f.data)) && strings.IndexByte(" \t\r\n,:", f.data[offset]) >= 0 {
+		offset++
+	}
+	return offset
+}
+
+/* A method that returns the line of an offset in the file */
+func (f *jsonFile) lineAt(offset int64) int {
+	if offset > int64(len(f.data)) {
+		offset = int64(len(f.data))
+	}
+	return bytes.Count(f.data[:offset], []byte("\n")) + 1
+}
+
+/* A method that returns the line of a path, or of the closest parent path that has one */
+func (f *jsonFile) line(path string) int {
+	for path != "" {
+		if line, ok := f.lines[path]; ok {
+			return line
+		}
+		i := strings.LastIndexAny(path, ".[")
+		if i < 0 {
+			break
+		}
+		path = path[:i]
+	}
+	return 0
+}
+
+/* A method that adds a problem at the line of a path */
+func (f *jsonFile) problem(problems *[]Problem, path, format string, args ...interface{}) {
+	*problems = append(*problems, Problem{File: f.name, Line: f.line(path), Message: fmt.Sprintf(format, args...)})
+}
+
+/* A function that sorts the problems by file and line */
+func sortProblems(problems []Problem) {
+	sort.SliceStable(problems, func(i, j int) bool {
+		if problems[i].File != problems[j].File {
+			return problems[i].File < problems[j].File
+		}
+		return problems[i].Line < problems[j].Line
+	})
+}
@@ -0,0 +1,109 @@
+package validate
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+/* A function that copies the content of the game into a map so that tests can change some of the files */
+func content(t *testing.T, changes map[string]string) fstest.MapFS {
+	t.Helper()
+	fsys := fstest.MapFS{}
+	for _, name := range append([]string{"json/enemies.json", "json/characters.json", "json/levels.json"}, designFiles...) {
+		data, err := os.ReadFile("../" + name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fsys[name] = &fstest.MapFile{Data: data}
+	}
+	for name, data := range changes {
+		fsys[name] = &fstest.MapFile{Data: []byte(data)}
+	}
+	return fsys
+}
+
+/* A function that fails the test unless there is exactly one problem and it is in a file on a line */
+func wantProblem(t *testing.T, problems []Problem, file string, line int, message string) {
+	t.Helper()
+	if len(problems) != 1 {
+		t.Fatalf("got the problems %v, want one in %s:%d", problems, file, line)
+	}
+	p := problems[0]
+	if p.File != file || p.Line != line || !strings.Contains(p.Message, message) {
+		t.Errorf("got the problem %q, want %s:%d with %q", p, file, line, message)
+	}
+}
+
+func TestTheContentIsValid(t *testing.T) {
+	if problems := Check(content(t, nil), Options{}); len(problems) != 0 {
+		t.Errorf("the content of the game has problems: %v", problems)
+	}
+}
+
+func TestLevelsAreNumberedInOrder(t *testing.T) {
+	levels := `{
+  "levels": [
+    {"number": 1, "enemies": 5, "time": 60, "enemy_types": ["grunt"]},
+    {
+      "number": 0,
+      "enemies": 5,
+      "time": 60,
+      "enemy_types": ["grunt"]
+    }
+  ]
+}`
+	problems := Check(content(t, map[string]string{"json/levels.json": levels}), Options{})
+	wantProblem(t, problems, "json/levels.json", 5, "must be number 2")
+}
+
+func TestUnknownEnemyTypeLine(t *testing.T) {
+	levels := `{
+  "levels": [
+    {
+      "number": 1,
+      "enemies": 5,
+      "time": 60,
+      "enemy_types": [
+        "grunt",
+        "ghost"
+      ]
+    }
+  ]
+}`
+	problems := Check(content(t, map[string]string{"json/levels.json": levels}), Options{})
+	wantProblem(t, problems, "json/levels.json", 9, `unknown enemy type "ghost"`)
+}
+
+func TestBossDesignProblemsLine(t *testing.T) {
+	boss := "  /-\\\n <###>\n  \\-/\n\nAnimation: Minion\n\nFrame 1:\n <o\n\nAnimation: Minion attack\n\nFrame 1:\n <o\nLaser: --*\n"
+	problems := Check(content(t, map[string]string{bossDesign: boss}), Options{})
+	wantProblem(t, problems, bossDesign, 12, `unknown event "laser"`)
+
+	boss = "  /-\\\n <###>\n  \\-/\n\nAnimation: Minion\n\nFrame 1:\n <o\n\nAnimation: Boss\n\nFrame 1:\n  /-\\\n <###>\n  \\-/\n\nFrame 2:\n  /-\\\n <####>\n  \\-/\n"
+	problems = Check(content(t, map[string]string{bossDesign: boss}), Options{})
+	wantProblem(t, problems, bossDesign, 17, "frame 2 of the boss is 3x6")
+
+	boss = "Animation: Mini\n\nFrame 1:\n <o\n"
+	problems = Check(content(t, map[string]string{bossDesign: boss}), Options{})
+	if len(problems) != 2 || problems[0].Line != 0 || problems[1].Line != 1 {
+		t.Errorf("got the problems %v, want the missing minion for the whole file and the unknown animation on line 1", problems)
+	}
+}
+
+func TestPackLevelsFollowTheBase(t *testing.T) {
+	base := content(t, nil)
+	pack := fstest.MapFS{"json/levels.json": {Data: []byte(`{"levels": [
+  {"number": 2, "enemies": 5, "time": 60},
+  {"number": 4, "enemies": 5, "time": 60},
+  {"number": 7, "enemies": 5, "time": 60}
+]}`)}}
+	problems := Check(pack, Options{Partial: true, Base: base})
+	wantProblem(t, problems, "json/levels.json", 4, "level 7 must be number 5")
+
+	problems = Check(pack, Options{Partial: true, Base: base, Replace: []string{"json/levels.json"}})
+	if len(problems) != 3 || problems[0].Line != 2 {
+		t.Errorf("got the problems %v, want every level out of order from line 2 when they replace the base levels", problems)
+	}
+}